@@ -1,17 +1,28 @@
 package main
 
 import (
+	"context"
 	"net/http"
+	"os"
 
 	"github.com/rs/zerolog/log"
 
 	"zadanie-6105/internal/api"
+	"zadanie-6105/internal/notification"
+	"zadanie-6105/internal/outbox"
 	"zadanie-6105/internal/postgres"
 	"zadanie-6105/internal/repository"
 	"zadanie-6105/internal/service"
+	"zadanie-6105/internal/storage"
+	"zadanie-6105/internal/webhook"
 )
 
-const defaultAddr = ":8080"
+const (
+	defaultAddr          = ":8080"
+	defaultAttachmentDir = "attachments"
+	defaultBucket        = "attachments"
+	defaultEmailFrom     = "Tender service <noreply@tender.local>"
+)
 
 func main() {
 	pool, err := postgres.Pool()
@@ -19,10 +30,99 @@ func main() {
 		log.Fatal().Stack().Err(err).Send()
 	}
 
-	a := api.New(service.NewService(repository.NewRepository(pool)))
+	policy := service.DefaultPolicy()
+	if path := os.Getenv("POLICY_FILE"); path != "" {
+		policy, err = service.LoadPolicy(path)
+		if err != nil {
+			log.Fatal().Stack().Err(err).Send()
+		}
+	}
+
+	store, err := newStorage()
+	if err != nil {
+		log.Fatal().Stack().Err(err).Send()
+	}
+
+	r := repository.NewRepository(pool)
+	s := service.NewService(r, store, policy)
+
+	if len(os.Args) > 1 && os.Args[1] == "verify" {
+		os.Exit(verify(s, os.Args[2:]))
+	}
+
+	publisher, err := newPublisher()
+	if err != nil {
+		log.Fatal().Stack().Err(err).Send()
+	}
+
+	sender, err := newEmailSender()
+	if err != nil {
+		log.Fatal().Stack().Err(err).Send()
+	}
+
+	publishers := outbox.Publishers{publisher, webhook.NewDispatcher(r), notification.NewDispatcher(r)}
+
+	go outbox.NewRelay(r, publishers).Run(context.Background())
+	go webhook.NewSender(r, nil).Run(context.Background())
+	go notification.NewMailer(r, sender).Run(context.Background())
+	go s.RunChangeFeed(context.Background())
+	go s.RunScheduledPublications(context.Background())
+	go s.RunReputation(context.Background())
+
+	a := api.New(s)
 
 	err = http.ListenAndServe(defaultAddr, a)
 	if err != nil {
 		log.Fatal().Stack().Err(err).Send()
 	}
 }
+
+func newPublisher() (outbox.Publisher, error) {
+	switch os.Getenv("OUTBOX_PUBLISHER") {
+	case "nats":
+		url := os.Getenv("NATS_URL")
+		if url == "" {
+			url = "nats://localhost:4222"
+		}
+
+		return outbox.NewNATSPublisher(url)
+	default:
+		return outbox.LogPublisher{}, nil
+	}
+}
+
+// newEmailSender sends through SMTP_ADDR when it is set, e.g. localhost:1025 for a local MailHog,
+// and only logs the emails otherwise.
+func newEmailSender() (notification.Sender, error) {
+	addr := os.Getenv("SMTP_ADDR")
+	if addr == "" {
+		return notification.LogSender{}, nil
+	}
+
+	from := os.Getenv("SMTP_FROM")
+	if from == "" {
+		from = defaultEmailFrom
+	}
+
+	return notification.NewSMTPSender(addr, from, os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"))
+}
+
+func newStorage() (service.Storage, error) {
+	switch os.Getenv("ATTACHMENT_STORAGE") {
+	case "s3":
+		bucket := os.Getenv("S3_BUCKET")
+		if bucket == "" {
+			bucket = defaultBucket
+		}
+
+		return storage.NewS3(context.Background(), os.Getenv("S3_ENDPOINT"), os.Getenv("S3_ACCESS_KEY"),
+			os.Getenv("S3_SECRET_KEY"), bucket, os.Getenv("S3_SECURE") == "true")
+	default:
+		dir := os.Getenv("ATTACHMENT_DIR")
+		if dir == "" {
+			dir = defaultAttachmentDir
+		}
+
+		return storage.NewLocal(dir)
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+	"zadanie-6105/internal/service"
+)
+
+const verifyUsage = "usage: zadanie-6105 verify tender|bid <id>"
+
+// verify walks the version hash chain of a tender or bid and returns the process exit code.
+func verify(s *service.Service, args []string) int {
+	if len(args) != 2 {
+		fmt.Fprintln(os.Stderr, verifyUsage)
+		return 2
+	}
+
+	id, err := uuid.Parse(args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, verifyUsage)
+		return 2
+	}
+
+	var v model.ChainVerification
+
+	switch args[0] {
+	case "tender":
+		v, err = s.VerifyTenderChain(context.Background(), id)
+	case "bid":
+		v, err = s.VerifyBidChain(context.Background(), id)
+	default:
+		fmt.Fprintln(os.Stderr, verifyUsage)
+		return 2
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if !v.Valid {
+		fmt.Printf("%s %s: chain broken at version %d: %s\n", args[0], id, v.BrokenAt, v.Reason)
+		return 1
+	}
+
+	fmt.Printf("%s %s: %d versions, chain intact\n", args[0], id, v.Versions)
+	return 0
+}
@@ -0,0 +1,8 @@
+-- Records the price in bid versions. Versions written before keep a null price, which leaves their hash as it
+-- was, and a rollback to one of them keeps the current price.
+begin;
+
+alter table bid_version
+    add column if not exists price numeric(18, 2);
+
+commit;
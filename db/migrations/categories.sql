@@ -0,0 +1,60 @@
+-- Moves a database created before the category tree from the service_type enum to categories.
+-- tender_version keeps the category name it was written with, so existing version hashes stay valid.
+begin;
+
+alter table employee
+    add column platform_admin boolean not null default false;
+
+create table category
+(
+    id        uuid primary key,
+    parent_id uuid references category (id),
+    name      text      not null,
+    created   timestamp not null,
+    unique nulls not distinct (parent_id, name)
+);
+
+insert into category (id, parent_id, name, created)
+values ('0191f5c0-0000-7000-8000-000000000001', null, 'Construction', '2024-09-01'),
+       ('0191f5c0-0000-7000-8000-000000000002', null, 'Delivery', '2024-09-01'),
+       ('0191f5c0-0000-7000-8000-000000000003', null, 'Manufacture', '2024-09-01');
+
+alter table tender
+    add column category_id uuid references category (id);
+update tender t
+set category_id = c.id
+from category c
+where c.parent_id is null
+  and c.name = t.service_type::text;
+alter table tender
+    alter column category_id set not null,
+    drop column service_type;
+create index tender_category_idx on tender (category_id);
+
+alter table tender_template
+    add column category_id uuid references category (id);
+update tender_template t
+set category_id = c.id
+from category c
+where c.parent_id is null
+  and c.name = t.service_type::text;
+alter table tender_template
+    alter column category_id set not null,
+    drop column service_type;
+
+alter table tender_version
+    add column category_id uuid references category (id),
+    add column category    text;
+update tender_version v
+set category_id = c.id,
+    category    = v.service_type::text
+from category c
+where c.parent_id is null
+  and c.name = v.service_type::text;
+alter table tender_version
+    alter column category set not null,
+    drop column service_type;
+
+drop type service_type;
+
+commit;
@@ -0,0 +1,24 @@
+-- Moves a database created before organization roles to explicit roles. Members from before were responsible
+-- for everything their organization did, so they are made admins; memberships added later without a role
+-- get the least privileged one.
+begin;
+
+do
+$$
+    begin
+        create type employee_role as enum ('viewer', 'editor', 'approver', 'admin');
+    exception
+        when duplicate_object then null;
+    end
+$$;
+
+alter table organization_employee
+    add column if not exists role employee_role;
+update organization_employee
+set role = 'admin'
+where role is null;
+alter table organization_employee
+    alter column role set not null,
+    alter column role set default 'viewer';
+
+commit;
@@ -0,0 +1,8 @@
+-- Records which fields a tender version hash covers. Existing versions were hashed without the category ID and
+-- stay on scheme 1, new ones are written with the current scheme.
+begin;
+
+alter table tender_version
+    add column if not exists hash_scheme smallint not null default 1;
+
+commit;
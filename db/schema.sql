@@ -1,7 +1,9 @@
 create table employee
 (
-    id       uuid primary key,
-    username text unique not null
+    id             uuid primary key,
+    username       text unique not null,
+    email          text,
+    platform_admin boolean     not null default false
 );
 
 create table organization
@@ -10,16 +12,36 @@ create table organization
     name text not null
 );
 
+create type employee_role as enum ('viewer', 'editor', 'approver', 'admin');
+
 create table organization_employee
 (
     organization_id uuid references organization (id) not null,
     employee_id     uuid references employee (id)     not null,
+    role            employee_role                     not null default 'viewer',
     unique (organization_id, employee_id)
 );
 
 create type tender_status as enum ('Created', 'Published', 'Closed');
 
-create type service_type as enum ('Construction', 'Delivery', 'Manufacture');
+create table category
+(
+    id        uuid primary key,
+    parent_id uuid references category (id),
+    name      text      not null,
+    created   timestamp not null,
+    unique nulls not distinct (parent_id, name)
+);
+
+-- The former service types, existing databases are moved over by db/migrations/categories.sql.
+insert into category (id, parent_id, name, created)
+values ('0191f5c0-0000-7000-8000-000000000001', null, 'Construction', '2024-09-01'),
+       ('0191f5c0-0000-7000-8000-000000000002', null, 'Delivery', '2024-09-01'),
+       ('0191f5c0-0000-7000-8000-000000000003', null, 'Manufacture', '2024-09-01');
+
+create type tender_visibility as enum ('Public', 'InviteOnly');
+
+create type tender_bid_mode as enum ('Open', 'Sealed', 'Auction');
 
 create table tender
 (
@@ -27,13 +49,74 @@ create table tender
     name            text                              not null,
     description     text                              not null,
     status          tender_status                     not null,
-    service_type    service_type                      not null,
+    category_id     uuid references category (id)     not null,
+    visibility      tender_visibility                 not null default 'Public',
+    bid_mode        tender_bid_mode                   not null default 'Open',
+    bids_deadline   timestamp,
+    bids_opened     timestamp,
+    publish_at      timestamp,
+    publish_by      uuid references employee (id),
+    publish_lease   timestamp,
     organization_id uuid references organization (id) not null,
     creator_id      uuid references employee (id)     not null,
     version_id      bigint                            not null,
     created         timestamp                         not null
 );
 
+create index tender_publish_idx on tender (publish_at) where publish_at is not null;
+create index tender_category_idx on tender (category_id);
+
+create table tender_template
+(
+    id              uuid primary key,
+    organization_id uuid references organization (id) not null,
+    name            text                              not null,
+    tender_name     text                              not null,
+    description     text                              not null,
+    category_id     uuid references category (id)     not null,
+    visibility      tender_visibility                 not null,
+    bid_mode        tender_bid_mode                   not null,
+    creator_id      uuid references employee (id)     not null,
+    created         timestamp                         not null
+);
+
+create index tender_template_organization_idx on tender_template (organization_id, name);
+
+create type invitation_status as enum ('Pending', 'Accepted', 'Declined');
+
+create table tender_invitation
+(
+    id              uuid primary key,
+    tender_id       uuid references tender (id)       not null,
+    organization_id uuid references organization (id),
+    employee_id     uuid references employee (id),
+    status          invitation_status                 not null,
+    inviter_id      uuid references employee (id)     not null,
+    created         timestamp                         not null,
+    responded       timestamp,
+    check (num_nonnulls(organization_id, employee_id) = 1),
+    unique (tender_id, organization_id),
+    unique (tender_id, employee_id)
+);
+
+create type answer_visibility as enum ('Private', 'Public');
+
+create table tender_question
+(
+    id                uuid primary key,
+    tender_id         uuid references tender (id)   not null,
+    author_id         uuid references employee (id) not null,
+    question          text                          not null,
+    answer            text,
+    answer_visibility answer_visibility,
+    answerer_id       uuid references employee (id),
+    tender_version_id bigint,
+    created           timestamp                     not null,
+    answered          timestamp
+);
+
+create index tender_question_tender_idx on tender_question (tender_id, created);
+
 create table tender_version
 (
     id           bigint,
@@ -41,11 +124,31 @@ create table tender_version
     name         text                        not null,
     description  text                        not null,
     status       tender_status               not null,
-    service_type service_type                not null,
+    category_id  uuid references category (id),
+    category     text                        not null,
+    employee_id  uuid references employee (id),
+    lots         jsonb,
+    attachments  uuid[],
     created      timestamp                   not null,
+    hash_scheme  smallint                    not null default 1,
+    hash         text                        not null,
+    prev_hash    text                        not null,
     unique (tender_id, id)
 );
 
+create type lot_status as enum ('Open', 'Awarded', 'Canceled', 'Removed');
+
+create table tender_lot
+(
+    id             uuid primary key,
+    tender_id      uuid references tender (id) not null,
+    name           text                        not null,
+    description    text                        not null,
+    status         lot_status                  not null,
+    awarded_bid_id uuid,
+    created        timestamp                   not null
+);
+
 create type bid_status as enum ('Created', 'Published', 'Canceled', 'Approved', 'Rejected');
 
 create type creator_type as enum ('Organization', 'User');
@@ -60,10 +163,34 @@ create table bid
     creator_type    creator_type                      not null,
     creator_id      uuid references employee (id)     not null,
     organization_id uuid references organization (id) not null,
+    price           numeric(18, 2),
     version_id      bigint                            not null,
     created         timestamp                         not null
 );
 
+create table auction
+(
+    tender_id         uuid primary key references tender (id),
+    starts            timestamp                     not null,
+    ends              timestamp                     not null,
+    step              numeric(18, 2)                not null,
+    extension_seconds integer                       not null,
+    creator_id        uuid references employee (id) not null,
+    created           timestamp                     not null
+);
+
+create table auction_offer
+(
+    id          bigserial primary key,
+    tender_id   uuid references auction (tender_id) not null,
+    bid_id      uuid references bid (id)            not null,
+    price       numeric(18, 2)                      not null,
+    employee_id uuid references employee (id)      not null,
+    created     timestamp                           not null
+);
+
+create index auction_offer_tender_price_idx on auction_offer (tender_id, price);
+
 create table bid_version
 (
     id          bigint                   not null,
@@ -71,14 +198,324 @@ create table bid_version
     name        text                     not null,
     description text                     not null,
     status      bid_status               not null,
-    created_    timestamp                not null,
+    price       numeric(18, 2),
+    attachments uuid[],
+    created     timestamp                not null,
+    hash        text                     not null,
+    prev_hash   text                     not null,
     unique (bid_id, id)
 );
 
-create table bid_agreement
+create table attachment
+(
+    id           uuid primary key,
+    entity_type  text                          not null,
+    entity_id    uuid                          not null,
+    name         text                          not null,
+    content_type text                          not null,
+    size         bigint                        not null,
+    checksum     text                          not null,
+    storage_key  text                          not null,
+    uploader_id  uuid references employee (id) not null,
+    created      timestamp                     not null,
+    removed      timestamp
+);
+
+create index attachment_entity_idx on attachment (entity_type, entity_id);
+
+create table tender_criterion
 (
+    id        uuid primary key,
+    tender_id uuid references tender (id) not null,
+    name      text                        not null,
+    weight    numeric(6, 2)               not null check (weight > 0),
+    created   timestamp                   not null
+);
+
+create table bid_score
+(
+    bid_id       uuid references bid (id)              not null,
+    criterion_id uuid references tender_criterion (id) not null,
+    employee_id  uuid references employee (id)         not null,
+    score        numeric(4, 2)                         not null check (score between 0 and 10),
+    updated      timestamp                             not null,
+    primary key (bid_id, criterion_id, employee_id)
+);
+
+create table bid_comment
+(
+    id             uuid primary key,
+    bid_id         uuid references bid (id)          not null,
+    bid_version_id bigint                            not null,
+    parent_id      uuid references bid_comment (id),
+    author_id      uuid references employee (id)     not null,
+    body           text                              not null,
+    created        timestamp                         not null,
+    foreign key (bid_id, bid_version_id) references bid_version (bid_id, id)
+);
+
+create index bid_comment_bid_idx on bid_comment (bid_id, created);
+
+create table bid_comment_mention
+(
+    comment_id  uuid references bid_comment (id) not null,
+    employee_id uuid references employee (id)    not null,
+    primary key (comment_id, employee_id)
+);
+
+create table bid_comment_read
+(
+    bid_id      uuid references bid (id)      not null,
+    employee_id uuid references employee (id) not null,
+    read_until  timestamp                     not null,
+    primary key (bid_id, employee_id)
+);
+
+create table bid_review
+(
+    id          uuid primary key,
     bid_id      uuid references bid (id)      not null,
+    author_id   uuid references employee (id) not null,
+    description text                          not null,
+    rating      smallint check (rating between 1 and 5),
+    created     timestamp                     not null
+);
+
+create index bid_review_bid_idx on bid_review (bid_id, created);
+
+create table bid_delivery
+(
+    bid_id      uuid primary key references bid (id),
+    on_time     boolean                       not null,
+    employee_id uuid references employee (id) not null,
+    created     timestamp                     not null
+);
+
+-- Recomputed periodically from bids, reviews and deliveries; the subject is the organization for bids
+-- made on behalf of one and the employee otherwise.
+create table supplier_reputation
+(
+    subject_type creator_type not null,
+    subject_id   uuid         not null,
+    bids         int          not null,
+    approved     int          not null,
+    withdrawn    int          not null,
+    reviews      int          not null,
+    rating_sum   int          not null,
+    deliveries   int          not null,
+    on_time      int          not null,
+    rating       numeric(3, 2),
+    computed     timestamp    not null,
+    primary key (subject_type, subject_id)
+);
+
+create table bid_lot
+(
+    bid_id uuid references bid (id)        not null,
+    lot_id uuid references tender_lot (id) not null,
+    primary key (bid_id, lot_id)
+);
+
+-- Staged approval of bids on the tenders of an organization, per category or, without one, for the rest.
+create table approval_workflow
+(
+    id              uuid primary key,
+    organization_id uuid references organization (id) not null,
+    category_id     uuid references category (id),
+    name            text                              not null,
+    creator_id      uuid references employee (id)     not null,
+    updated         timestamp                         not null,
+    unique nulls not distinct (organization_id, category_id)
+);
+
+create table approval_stage
+(
+    workflow_id  uuid references approval_workflow (id) on delete cascade not null,
+    position     smallint                                                 not null,
+    name         text                                                     not null,
+    quorum       smallint                                                 not null check (quorum > 0),
+    approver_ids uuid[]                                                   not null,
+    primary key (workflow_id, position)
+);
+
+-- Approvers of an organization away for a period and the colleagues deciding on bids in their place.
+create table approval_delegation
+(
+    id              uuid primary key,
+    organization_id uuid references organization (id) not null,
+    delegator_id    uuid references employee (id)     not null,
+    delegate_id     uuid references employee (id)     not null,
+    starts          timestamp                         not null,
+    ends            timestamp                         not null,
+    creator_id      uuid references employee (id)     not null,
+    created         timestamp                         not null,
+    check (delegator_id <> delegate_id),
+    check (starts < ends)
+);
+
+create index approval_delegation_delegate_idx on approval_delegation (delegate_id, delegator_id);
+
+-- Votes on a bid, or on one of its lots. Stage is the position of the approval stage voted in, null
+-- for tenders without workflow. Employee is the approver whose vote it is, delegate whoever cast it
+-- in their place.
+create table bid_agreement
+(
+    bid_id      uuid references bid (id)        not null,
+    lot_id      uuid references tender_lot (id),
+    stage       smallint,
+    employee_id uuid references employee (id)   not null,
+    delegate_id uuid references employee (id),
+    status      bid_status                      not null,
+    created     timestamp                       not null,
+    unique nulls not distinct (bid_id, lot_id, stage, employee_id)
+);
+
+-- Workflow a bid is decided under, with its stages as they were at the first vote, so that changing
+-- or deleting the workflow leaves bids already in approval alone.
+create table bid_workflow
+(
+    bid_id      uuid primary key references bid (id),
+    workflow_id uuid references approval_workflow (id) on delete set null,
+    stages      jsonb     not null,
+    created     timestamp not null
+);
+
+-- Settled approval stages of a bid, or of one of its lots. The name is kept as it was when the stage settled.
+create table bid_stage
+(
+    bid_id  uuid references bid (id)        not null,
+    lot_id  uuid references tender_lot (id),
+    stage   smallint                        not null,
+    name    text                            not null,
+    status  bid_status                      not null,
+    created timestamp                       not null,
+    unique nulls not distinct (bid_id, lot_id, stage)
+);
+
+create table supplier_blacklist
+(
+    id                      uuid primary key,
+    organization_id         uuid references organization (id) not null,
+    blocked_organization_id uuid references organization (id),
+    blocked_employee_id     uuid references employee (id),
+    reason                  text                              not null,
+    expires                 timestamp,
+    creator_id              uuid references employee (id)     not null,
+    created                 timestamp                         not null,
+    check (num_nonnulls(blocked_organization_id, blocked_employee_id) = 1),
+    unique (organization_id, blocked_organization_id),
+    unique (organization_id, blocked_employee_id)
+);
+
+-- Employees of the organization allowed to act on its tenders despite also belonging to a bidder.
+create table conflict_exemption
+(
+    id              uuid primary key,
+    organization_id uuid references organization (id) not null,
+    employee_id     uuid references employee (id)     not null,
+    reason          text                              not null,
+    creator_id      uuid references employee (id)     not null,
+    created         timestamp                         not null,
+    unique (organization_id, employee_id)
+);
+
+create table audit_event
+(
+    id              bigserial primary key,
+    actor_id        uuid references employee (id) not null,
+    action          text                          not null,
+    entity_type     text                          not null,
+    entity_id       uuid                          not null,
+    organization_id uuid references organization (id),
+    before          jsonb,
+    after           jsonb,
+    request_id      text                          not null,
+    created         timestamp                     not null
+);
+
+create index audit_event_entity_idx on audit_event (entity_type, entity_id);
+create index audit_event_created_idx on audit_event (created);
+
+create table outbox_event
+(
+    id               bigserial primary key,
+    type             text      not null,
+    entity_type      text      not null,
+    entity_id        uuid      not null,
+    organization_ids uuid[]    not null,
+    payload          jsonb     not null,
+    attempts         int       not null default 0,
+    next_attempt     timestamp not null,
+    last_error       text,
+    published        timestamp,
+    created          timestamp not null
+);
+
+create index outbox_event_pending_idx on outbox_event (next_attempt) where published is null;
+
+create table webhook
+(
+    id              uuid primary key,
+    organization_id uuid references organization (id) not null,
+    url             text                              not null,
+    event_types     text[]                            not null,
+    secret          text                              not null,
+    creator_id      uuid references employee (id)     not null,
+    created         timestamp                         not null
+);
+
+create type webhook_delivery_status as enum ('Pending', 'Delivered', 'Failed');
+
+create table webhook_delivery
+(
+    id            bigserial primary key,
+    webhook_id    uuid references webhook (id) on delete cascade not null,
+    event_id      bigint references outbox_event (id)            not null,
+    event_type    text                                           not null,
+    payload       jsonb                                          not null,
+    status        webhook_delivery_status                        not null,
+    attempts      int                                            not null default 0,
+    response_code int,
+    last_error    text,
+    next_attempt  timestamp                                      not null,
+    delivered     timestamp,
+    created       timestamp                                      not null,
+    unique (webhook_id, event_id)
+);
+
+create index webhook_delivery_pending_idx on webhook_delivery (next_attempt) where status = 'Pending';
+
+create type notification_email_status as enum ('Pending', 'Sent', 'Failed');
+
+create table notification
+(
+    id           bigserial primary key,
+    employee_id  uuid references employee (id)       not null,
+    event_id     bigint references outbox_event (id) not null,
+    event_type   text                                not null,
+    entity_type  text                                not null,
+    entity_id    uuid                                not null,
+    subject      text                                not null,
+    body         text                                not null,
+    in_app       boolean                             not null,
+    read         timestamp,
+    email_status notification_email_status,
+    attempts     int                                 not null default 0,
+    last_error   text,
+    next_attempt timestamp                           not null,
+    sent         timestamp,
+    created      timestamp                           not null,
+    unique (employee_id, event_id)
+);
+
+create index notification_inbox_idx on notification (employee_id, id) where in_app;
+create index notification_email_pending_idx on notification (next_attempt) where email_status = 'Pending';
+
+create table notification_preference
+(
     employee_id uuid references employee (id) not null,
-    status      bid_status                    not null,
-    unique (bid_id, employee_id)
+    event_type  text                          not null,
+    in_app      boolean                       not null,
+    email       boolean                       not null,
+    primary key (employee_id, event_type)
 );
@@ -2,10 +2,13 @@ package api
 
 import (
 	"context"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 
 	"zadanie-6105/internal/model"
 )
@@ -15,13 +18,85 @@ type Service interface {
 	Tender(ctx context.Context, username string, opts model.TenderFilter) (model.Tender, error)
 	CreateTender(ctx context.Context, username string, tender model.Tender) (model.Tender, error)
 	UpdateTender(ctx context.Context, username string, tender model.Tender) (model.Tender, error)
+	ScheduleTenderPublication(ctx context.Context, username string, tenderID uuid.UUID, publishAt time.Time) (model.Tender, error)
+	CancelTenderPublication(ctx context.Context, username string, tenderID uuid.UUID) (model.Tender, error)
+	CloneTender(ctx context.Context, username string, tenderID uuid.UUID, versionID int64) (model.Tender, error)
+	Categories(ctx context.Context) ([]model.Category, error)
+	CreateCategory(ctx context.Context, username string, category model.Category) (model.Category, error)
+	UpdateCategory(ctx context.Context, username string, category model.Category) (model.Category, error)
+	DeleteCategory(ctx context.Context, username string, categoryID uuid.UUID) error
+	TenderTemplates(ctx context.Context, username string, organizationID uuid.UUID) ([]model.TenderTemplate, error)
+	CreateTenderTemplate(ctx context.Context, username string, template model.TenderTemplate) (model.TenderTemplate, error)
+	DeleteTenderTemplate(ctx context.Context, username string, templateID uuid.UUID) error
+	CreateTenderFromTemplate(ctx context.Context, username string, templateID uuid.UUID, values map[string]string) (model.Tender, error)
 	RollbackTender(ctx context.Context, username string, tenderID uuid.UUID, versionID int64) (model.Tender, error)
+	TransferTender(ctx context.Context, username string, tenderID uuid.UUID, owner string) (model.Tender, error)
+	OpenTenderBids(ctx context.Context, username string, tenderID uuid.UUID) (model.Tender, error)
 	Bids(ctx context.Context, username string, opts model.BidFilter) ([]model.Bid, error)
 	Bid(ctx context.Context, username string, bidID uuid.UUID) (model.Bid, error)
 	CreateBid(ctx context.Context, username string, bid model.Bid) (model.Bid, error)
 	UpdateBid(ctx context.Context, username string, bid model.Bid) (model.Bid, error)
 	RollbackBid(ctx context.Context, username string, tenderID uuid.UUID, versionID int64) (model.Bid, error)
-	SubmitBidDecision(ctx context.Context, username string, bidID uuid.UUID, status model.BidStatus) (model.Bid, error)
+	SubmitBidDecision(ctx context.Context, username string, bidID, lotID uuid.UUID, status model.BidStatus, onBehalfOf string) (model.Bid, error)
+	SubmitBidFeedback(ctx context.Context, username string, bidID uuid.UUID, feedback string, rating int) (model.Bid, error)
+	ConfirmDelivery(ctx context.Context, username string, bidID uuid.UUID, onTime bool) (model.Bid, error)
+	BidReviews(ctx context.Context, username string, tenderID uuid.UUID, author string, opts model.ReviewFilter) ([]model.Review, error)
+	OrganizationReputation(ctx context.Context, username string, organizationID uuid.UUID) (model.Reputation, error)
+	ConflictExemptions(ctx context.Context, username string, organizationID uuid.UUID) ([]model.ConflictExemption, error)
+	CreateConflictExemption(ctx context.Context, username string, exemption model.ConflictExemption, exempted string) (model.ConflictExemption, error)
+	DeleteConflictExemption(ctx context.Context, username string, organizationID, exemptionID uuid.UUID) error
+	Blacklist(ctx context.Context, username string, organizationID uuid.UUID) ([]model.BlacklistEntry, error)
+	CreateBlacklistEntry(ctx context.Context, username string, entry model.BlacklistEntry, blocked string) (model.BlacklistEntry, error)
+	DeleteBlacklistEntry(ctx context.Context, username string, organizationID, entryID uuid.UUID) error
+	Workflows(ctx context.Context, username string, organizationID uuid.UUID) ([]model.Workflow, error)
+	SaveWorkflow(ctx context.Context, username string, workflow model.Workflow, approvers [][]string) (model.Workflow, error)
+	DeleteWorkflow(ctx context.Context, username string, organizationID, workflowID uuid.UUID) error
+	BidStages(ctx context.Context, username string, bidID uuid.UUID) ([]model.StageResult, error)
+	Delegations(ctx context.Context, username string, organizationID uuid.UUID) ([]model.Delegation, error)
+	CreateDelegation(ctx context.Context, username string, delegation model.Delegation, delegator, delegate string) (model.Delegation, error)
+	DeleteDelegation(ctx context.Context, username string, organizationID, delegationID uuid.UUID) error
+	Audit(ctx context.Context, username string, actor string, opts model.AuditFilter) ([]model.AuditEvent, error)
+	VerifyTender(ctx context.Context, username string, tenderID uuid.UUID) (model.ChainVerification, error)
+	VerifyBid(ctx context.Context, username string, bidID uuid.UUID) (model.ChainVerification, error)
+	Webhooks(ctx context.Context, username string, organizationID uuid.UUID) ([]model.Webhook, error)
+	CreateWebhook(ctx context.Context, username string, webhook model.Webhook) (model.Webhook, error)
+	DeleteWebhook(ctx context.Context, username string, webhookID uuid.UUID) error
+	WebhookDeliveries(ctx context.Context, username string, opts model.WebhookDeliveryFilter) ([]model.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, username string, webhookID uuid.UUID, deliveryID int64) (model.WebhookDelivery, error)
+	Invitations(ctx context.Context, username string, opts model.InvitationFilter) ([]model.Invitation, error)
+	MyInvitations(ctx context.Context, username string, opts model.InvitationFilter) ([]model.Invitation, error)
+	CreateInvitation(ctx context.Context, username string, invitation model.Invitation, invitee string) (model.Invitation, error)
+	DeleteInvitation(ctx context.Context, username string, tenderID, invitationID uuid.UUID) error
+	RespondInvitation(ctx context.Context, username string, invitationID uuid.UUID, status model.InvitationStatus) (model.Invitation, error)
+	Auction(ctx context.Context, username string, tenderID uuid.UUID) (model.Auction, error)
+	CreateAuction(ctx context.Context, username string, auction model.Auction) (model.Auction, error)
+	PlaceOffer(ctx context.Context, username string, bidID uuid.UUID, price float64) (model.Auction, error)
+	Lots(ctx context.Context, username string, tenderID uuid.UUID) ([]model.Lot, error)
+	CreateLot(ctx context.Context, username string, lot model.Lot) (model.Lot, error)
+	UpdateLot(ctx context.Context, username string, lot model.Lot) (model.Lot, error)
+	RemoveLot(ctx context.Context, username string, tenderID, lotID uuid.UUID) (model.Lot, error)
+	CancelLot(ctx context.Context, username string, tenderID, lotID uuid.UUID) (model.Lot, error)
+	Criteria(ctx context.Context, username string, tenderID uuid.UUID) ([]model.Criterion, error)
+	ReplaceCriteria(ctx context.Context, username string, tenderID uuid.UUID, criteria []model.Criterion) ([]model.Criterion, error)
+	BidScores(ctx context.Context, username string, bidID uuid.UUID) ([]model.Score, error)
+	ScoreBid(ctx context.Context, username string, bidID uuid.UUID, scores []model.Score) ([]model.Score, error)
+	TenderComparison(ctx context.Context, username string, tenderID uuid.UUID) (model.Comparison, error)
+	Questions(ctx context.Context, username string, opts model.QuestionFilter) ([]model.Question, error)
+	AskQuestion(ctx context.Context, username string, question model.Question) (model.Question, error)
+	AnswerQuestion(ctx context.Context, username string, answer model.Question, change model.Tender) (model.Question, error)
+	Attachments(ctx context.Context, username string, entityType model.EntityType, entityID uuid.UUID) ([]model.Attachment, error)
+	Attachment(ctx context.Context, username string, entityType model.EntityType, entityID, attachmentID uuid.UUID) (model.Attachment, io.ReadCloser, error)
+	UploadAttachment(ctx context.Context, username string, attachment model.Attachment, content io.Reader) (model.Attachment, error)
+	RemoveAttachment(ctx context.Context, username string, entityType model.EntityType, entityID, attachmentID uuid.UUID) (model.Attachment, error)
+	Notifications(ctx context.Context, username string, opts model.NotificationFilter) ([]model.Notification, error)
+	MarkNotificationRead(ctx context.Context, username string, notificationID int64) (model.Notification, error)
+	MarkNotificationsRead(ctx context.Context, username string) error
+	NotificationPreferences(ctx context.Context, username string) ([]model.NotificationPreference, error)
+	UpdateNotificationPreferences(ctx context.Context, username string, preferences []model.NotificationPreference) ([]model.NotificationPreference, error)
+	Comments(ctx context.Context, username string, opts model.CommentFilter) ([]model.Comment, error)
+	CreateComment(ctx context.Context, username string, comment model.Comment) (model.Comment, error)
+	MarkCommentsRead(ctx context.Context, username string, bidID uuid.UUID) error
+	Changes(ctx context.Context, username string, tenderID uuid.UUID) (<-chan model.Change, error)
 }
 
 type API struct {
@@ -35,15 +110,27 @@ func New(service Service) *API {
 		service: service,
 	}
 
+	a.Use(middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, requestID string) {
+			c.SetRequest(c.Request().WithContext(model.WithRequestID(c.Request().Context(), requestID)))
+		},
+	}))
+
 	api := a.Group("/api")
 	{
 		api.GET("/ping", a.ping)
+		api.GET("/audit", a.audit)
+		api.GET("/stream", a.stream)
 
 		tenders := api.Group("/tenders")
 		{
 			tenders.GET("", a.tenders)
 			tenders.GET("/my", a.myTenders)
 			tenders.POST("/new", a.createTender)
+			tenders.GET("/templates", a.tenderTemplates)
+			tenders.POST("/templates/new", a.createTenderTemplate)
+			tenders.DELETE("/templates/:templateId", a.deleteTenderTemplate)
+			tenders.POST("/templates/:templateId/new", a.createTenderFromTemplate)
 
 			tender := tenders.Group("/:tenderId")
 			{
@@ -51,6 +138,32 @@ func New(service Service) *API {
 				tender.PATCH("/edit", a.updateTender)
 				tender.PUT("/status", a.updateTenderStatus)
 				tender.PUT("/rollback/:version", a.rollbackTender)
+				tender.PUT("/owner", a.transferTender)
+				tender.PUT("/publication", a.scheduleTenderPublication)
+				tender.DELETE("/publication", a.cancelTenderPublication)
+				tender.POST("/clone", a.cloneTender)
+				tender.PUT("/bids/open", a.openTenderBids)
+				tender.GET("/auction", a.auction)
+				tender.PUT("/auction", a.createAuction)
+				tender.GET("/lots", a.lots)
+				tender.POST("/lots", a.createLot)
+				tender.PATCH("/lots/:lotId", a.updateLot)
+				tender.DELETE("/lots/:lotId", a.removeLot)
+				tender.PUT("/lots/:lotId/cancel", a.cancelLot)
+				tender.GET("/criteria", a.criteria)
+				tender.PUT("/criteria", a.replaceCriteria)
+				tender.GET("/comparison", a.tenderComparison)
+				tender.GET("/questions", a.questions)
+				tender.POST("/questions", a.askQuestion)
+				tender.PUT("/questions/:questionId/answer", a.answerQuestion)
+				tender.GET("/attachments", a.tenderAttachments)
+				tender.POST("/attachments", a.uploadTenderAttachment)
+				tender.GET("/attachments/:attachmentId", a.downloadTenderAttachment)
+				tender.DELETE("/attachments/:attachmentId", a.removeTenderAttachment)
+				tender.GET("/verify", a.verifyTender)
+				tender.GET("/invitations", a.invitations)
+				tender.POST("/invitations", a.createInvitation)
+				tender.DELETE("/invitations/:invitationId", a.deleteInvitation)
 			}
 		}
 
@@ -58,6 +171,7 @@ func New(service Service) *API {
 		{
 			bids.GET("/my", a.myBids)
 			bids.GET("/:tenderId/list", a.tenderBids)
+			bids.GET("/:tenderId/reviews", a.bidReviews)
 			bids.POST("/new", a.createBid)
 
 			bid := bids.Group("/:bidId")
@@ -67,6 +181,73 @@ func New(service Service) *API {
 				bid.PUT("/status", a.updateBidStatus)
 				bid.PUT("/rollback/:version", a.rollbackBid)
 				bid.PUT("/submit_decision", a.submitBidDecision)
+				bid.GET("/stages", a.bidStages)
+				bid.PUT("/feedback", a.submitBidFeedback)
+				bid.PUT("/delivery", a.confirmDelivery)
+				bid.PUT("/offer", a.placeOffer)
+				bid.GET("/scores", a.bidScores)
+				bid.PUT("/scores", a.scoreBid)
+				bid.GET("/comments", a.comments)
+				bid.POST("/comments", a.createComment)
+				bid.PUT("/comments/read", a.readComments)
+				bid.GET("/attachments", a.bidAttachments)
+				bid.POST("/attachments", a.uploadBidAttachment)
+				bid.GET("/attachments/:attachmentId", a.downloadBidAttachment)
+				bid.DELETE("/attachments/:attachmentId", a.removeBidAttachment)
+				bid.GET("/verify", a.verifyBid)
+			}
+		}
+
+		invitations := api.Group("/invitations")
+		{
+			invitations.GET("/my", a.myInvitations)
+			invitations.PUT("/:invitationId/respond", a.respondInvitation)
+		}
+
+		notifications := api.Group("/notifications")
+		{
+			notifications.GET("", a.notifications)
+			notifications.PUT("/read", a.readNotifications)
+			notifications.PUT("/:notificationId/read", a.readNotification)
+			notifications.GET("/preferences", a.notificationPreferences)
+			notifications.PUT("/preferences", a.updateNotificationPreferences)
+		}
+
+		organization := api.Group("/organizations/:organizationId")
+		{
+			organization.GET("/reputation", a.organizationReputation)
+			organization.GET("/exemptions", a.conflictExemptions)
+			organization.POST("/exemptions", a.createConflictExemption)
+			organization.DELETE("/exemptions/:exemptionId", a.deleteConflictExemption)
+			organization.GET("/blacklist", a.blacklist)
+			organization.POST("/blacklist", a.createBlacklistEntry)
+			organization.DELETE("/blacklist/:entryId", a.deleteBlacklistEntry)
+			organization.GET("/workflows", a.workflows)
+			organization.POST("/workflows", a.saveWorkflow)
+			organization.DELETE("/workflows/:workflowId", a.deleteWorkflow)
+			organization.GET("/delegations", a.delegations)
+			organization.POST("/delegations", a.createDelegation)
+			organization.DELETE("/delegations/:delegationId", a.deleteDelegation)
+		}
+
+		categories := api.Group("/categories")
+		{
+			categories.GET("", a.categories)
+			categories.POST("/new", a.createCategory)
+			categories.PATCH("/:categoryId/edit", a.updateCategory)
+			categories.DELETE("/:categoryId", a.deleteCategory)
+		}
+
+		webhooks := api.Group("/webhooks")
+		{
+			webhooks.GET("", a.webhooks)
+			webhooks.POST("/new", a.createWebhook)
+
+			webhook := webhooks.Group("/:webhookId")
+			{
+				webhook.DELETE("", a.deleteWebhook)
+				webhook.GET("/deliveries", a.webhookDeliveries)
+				webhook.PUT("/deliveries/:deliveryId/redeliver", a.redeliverWebhook)
 			}
 		}
 	}
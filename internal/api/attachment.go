@@ -0,0 +1,200 @@
+package api
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+// uploadOverhead is what an upload request may carry beyond the file itself: the multipart framing and headers.
+const uploadOverhead = 1 << 20
+
+func (a *API) tenderAttachments(c echo.Context) error {
+	return a.attachments(c, model.EntityTender, "tenderId")
+}
+
+func (a *API) uploadTenderAttachment(c echo.Context) error {
+	return a.uploadAttachment(c, model.EntityTender, "tenderId")
+}
+
+func (a *API) downloadTenderAttachment(c echo.Context) error {
+	return a.downloadAttachment(c, model.EntityTender, "tenderId")
+}
+
+func (a *API) removeTenderAttachment(c echo.Context) error {
+	return a.removeAttachment(c, model.EntityTender, "tenderId")
+}
+
+func (a *API) bidAttachments(c echo.Context) error {
+	return a.attachments(c, model.EntityBid, "bidId")
+}
+
+func (a *API) uploadBidAttachment(c echo.Context) error {
+	return a.uploadAttachment(c, model.EntityBid, "bidId")
+}
+
+func (a *API) downloadBidAttachment(c echo.Context) error {
+	return a.downloadAttachment(c, model.EntityBid, "bidId")
+}
+
+func (a *API) removeBidAttachment(c echo.Context) error {
+	return a.removeAttachment(c, model.EntityBid, "bidId")
+}
+
+func (a *API) attachments(c echo.Context, entityType model.EntityType, param string) error {
+	entityID, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	attachments, err := a.service.Attachments(c.Request().Context(), c.QueryParam("username"), entityType, entityID)
+	if err != nil {
+		return a.attachmentError(c, err)
+	}
+
+	r := make([]attachmentResponse, 0, len(attachments))
+	for _, at := range attachments {
+		r = append(r, a.attachmentFromModel(at))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+func (a *API) uploadAttachment(c echo.Context, entityType model.EntityType, param string) error {
+	entityID, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	// The body is capped before parsing, otherwise the multipart reader would buffer a file of any size
+	// before its size could be checked.
+	c.Request().Body = http.MaxBytesReader(c.Response(), c.Request().Body, model.MaxAttachmentSize+uploadOverhead)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return a.attachmentError(c, model.ErrAttachmentTooLarge)
+		}
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+	defer func() { _ = content.Close() }()
+
+	attachment := model.Attachment{
+		EntityType:  entityType,
+		EntityID:    entityID,
+		Name:        file.Filename,
+		ContentType: file.Header.Get(echo.HeaderContentType),
+		Size:        file.Size,
+	}
+
+	at, err := a.service.UploadAttachment(c.Request().Context(), c.QueryParam("username"), attachment, content)
+	if err != nil {
+		return a.attachmentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.attachmentFromModel(at))
+}
+
+func (a *API) downloadAttachment(c echo.Context, entityType model.EntityType, param string) error {
+	entityID, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	at, content, err := a.service.Attachment(c.Request().Context(), c.QueryParam("username"), entityType, entityID,
+		attachmentID)
+	if err != nil {
+		return a.attachmentError(c, err)
+	}
+	defer func() { _ = content.Close() }()
+
+	header := c.Response().Header()
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment", map[string]string{"filename": at.Name}))
+	header.Set(echo.HeaderContentLength, strconv.FormatInt(at.Size, 10))
+	header.Set("ETag", strconv.Quote(at.Checksum))
+
+	return c.Stream(http.StatusOK, at.ContentType, content)
+}
+
+func (a *API) removeAttachment(c echo.Context, entityType model.EntityType, param string) error {
+	entityID, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	attachmentID, err := uuid.Parse(c.Param("attachmentId"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	at, err := a.service.RemoveAttachment(c.Request().Context(), c.QueryParam("username"), entityType, entityID,
+		attachmentID)
+	if err != nil {
+		return a.attachmentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.attachmentFromModel(at))
+}
+
+func (a *API) attachmentError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrTenderOrVersionNotFound) || errors.Is(err, model.ErrTenderOrBidNotFound) ||
+		errors.Is(err, model.ErrAttachmentNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrAttachmentTooLarge) {
+		return c.JSON(http.StatusRequestEntityTooLarge, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrAttachmentType) {
+		return c.JSON(http.StatusUnsupportedMediaType, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidAttachment) || errors.Is(err, model.ErrBidsSealed) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type attachmentResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	ContentType string    `json:"contentType"`
+	Size        int64     `json:"size"`
+	Checksum    string    `json:"sha256"`
+	UploaderID  uuid.UUID `json:"uploaderId"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func (a *API) attachmentFromModel(attachment model.Attachment) attachmentResponse {
+	return attachmentResponse{
+		ID:          attachment.ID,
+		Name:        attachment.Name,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		Checksum:    attachment.Checksum,
+		UploaderID:  attachment.UploaderID,
+		CreatedAt:   attachment.Created,
+	}
+}
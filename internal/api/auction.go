@@ -0,0 +1,132 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type auctionRequest struct {
+	Username string    `query:"username"`
+	TenderID uuid.UUID `param:"tenderId"`
+}
+
+func (a *API) auction(c echo.Context) error {
+	var req auctionRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	auction, err := a.service.Auction(c.Request().Context(), req.Username, req.TenderID)
+	if err != nil {
+		return a.auctionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.auctionFromModel(auction))
+}
+
+type createAuctionRequest struct {
+	TenderID         uuid.UUID `param:"tenderId"`
+	StartsAt         time.Time `json:"startsAt"`
+	EndsAt           time.Time `json:"endsAt"`
+	Step             float64   `json:"step"`
+	ExtensionSeconds int64     `json:"extensionSeconds"`
+}
+
+func (a *API) createAuction(c echo.Context) error {
+	var req createAuctionRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	auction := model.Auction{
+		TenderID:  req.TenderID,
+		Starts:    req.StartsAt,
+		Ends:      req.EndsAt,
+		Step:      req.Step,
+		Extension: time.Duration(req.ExtensionSeconds) * time.Second,
+	}
+
+	au, err := a.service.CreateAuction(c.Request().Context(), c.QueryParam("username"), auction)
+	if err != nil {
+		return a.auctionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.auctionFromModel(au))
+}
+
+type placeOfferRequest struct {
+	BidID uuid.UUID `param:"bidId"`
+}
+
+func (a *API) placeOffer(c echo.Context) error {
+	var req placeOfferRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	price, err := strconv.ParseFloat(c.QueryParam("price"), 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	au, err := a.service.PlaceOffer(c.Request().Context(), c.QueryParam("username"), req.BidID, price)
+	if err != nil {
+		return a.auctionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.auctionFromModel(au))
+}
+
+func (a *API) auctionError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrTenderOrVersionNotFound) || errors.Is(err, model.ErrTenderOrBidNotFound) ||
+		errors.Is(err, model.ErrAuctionNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidAuction) || errors.Is(err, model.ErrInvalidPrice) ||
+		errors.Is(err, model.ErrAuctionClosed) || errors.Is(err, model.ErrOfferTooHigh) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type auctionResponse struct {
+	TenderID         uuid.UUID `json:"tenderId"`
+	StartsAt         time.Time `json:"startsAt"`
+	EndsAt           time.Time `json:"endsAt"`
+	Step             float64   `json:"step"`
+	ExtensionSeconds int64     `json:"extensionSeconds"`
+	BestPrice        float64   `json:"bestPrice,omitempty"`
+	Offers           int       `json:"offers"`
+}
+
+// auctionFromModel leaves out the leading bid: participants compete on the price alone.
+func (a *API) auctionFromModel(auction model.Auction) auctionResponse {
+	return auctionResponse{
+		TenderID:         auction.TenderID,
+		StartsAt:         auction.Starts,
+		EndsAt:           auction.Ends,
+		Step:             auction.Step,
+		ExtensionSeconds: int64(auction.Extension / time.Second),
+		BestPrice:        auction.BestPrice,
+		Offers:           auction.Offers,
+	}
+}
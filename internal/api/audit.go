@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type auditRequest struct {
+	Username   string    `query:"username"`
+	EntityType string    `query:"entityType"`
+	EntityID   uuid.UUID `query:"entityId"`
+	Actor      string    `query:"actor"`
+	From       time.Time `query:"from"`
+	To         time.Time `query:"to"`
+	Limit      uint64    `query:"limit"`
+	Offset     uint64    `query:"offset"`
+}
+
+func (a *API) audit(c echo.Context) error {
+	var req auditRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	opts := model.AuditFilter{
+		EntityType: model.EntityType(req.EntityType),
+		EntityID:   req.EntityID,
+		From:       req.From,
+		To:         req.To,
+		Offset:     req.Offset,
+		Limit:      req.Limit,
+	}
+
+	events, err := a.service.Audit(c.Request().Context(), req.Username, req.Actor, opts)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, a.auditEventsFromModel(events))
+}
+
+type auditEventResponse struct {
+	ID             int64           `json:"id"`
+	ActorID        uuid.UUID       `json:"actorId"`
+	Action         string          `json:"action"`
+	EntityType     string          `json:"entityType"`
+	EntityID       uuid.UUID       `json:"entityId"`
+	OrganizationID uuid.UUID       `json:"organizationId"`
+	Before         json.RawMessage `json:"before,omitempty"`
+	After          json.RawMessage `json:"after,omitempty"`
+	RequestID      string          `json:"requestId"`
+	CreatedAt      time.Time       `json:"createdAt"`
+}
+
+func (a *API) auditEventsFromModel(events []model.AuditEvent) []auditEventResponse {
+	var r = make([]auditEventResponse, 0, len(events))
+	for _, e := range events {
+		r = append(r, a.auditEventFromModel(e))
+	}
+
+	return r
+}
+
+func (a *API) auditEventFromModel(event model.AuditEvent) auditEventResponse {
+	return auditEventResponse{
+		ID:             event.ID,
+		ActorID:        event.ActorID,
+		Action:         string(event.Action),
+		EntityType:     string(event.EntityType),
+		EntityID:       event.EntityID,
+		OrganizationID: event.OrganizationID,
+		Before:         event.Before,
+		After:          event.After,
+		RequestID:      event.RequestID,
+		CreatedAt:      event.Created,
+	}
+}
@@ -16,6 +16,7 @@ type tenderBidsRequest struct {
 	Username string    `query:"username"`
 	Limit    uint64    `query:"limit"`
 	Offset   uint64    `query:"offset"`
+	SortBy   string    `query:"sortBy"`
 }
 
 func (a *API) tenderBids(c echo.Context) error {
@@ -26,8 +27,13 @@ func (a *API) tenderBids(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
 	}
 
+	if req.SortBy != "" && model.BidSort(req.SortBy) != model.BidSortScore {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
 	opts := model.BidFilter{
 		TenderID: req.TenderID,
+		SortBy:   model.BidSort(req.SortBy),
 		Offset:   req.Offset,
 		Limit:    req.Limit,
 	}
@@ -89,6 +95,12 @@ func (a *API) bidStatus(c echo.Context) error {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrBidNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -96,13 +108,15 @@ func (a *API) bidStatus(c echo.Context) error {
 }
 
 type createBidRequest struct {
-	Name           string    `json:"name"`
-	Description    string    `json:"description"`
-	Status         string    `json:"status"`
-	TenderID       uuid.UUID `json:"tenderId"`
-	CreatorType    string    `json:"creatorType"`
-	OrganizationID uuid.UUID `json:"organizationId"`
-	Username       string    `json:"creatorUsername"`
+	Name           string      `json:"name"`
+	Description    string      `json:"description"`
+	Status         string      `json:"status"`
+	TenderID       uuid.UUID   `json:"tenderId"`
+	CreatorType    string      `json:"creatorType"`
+	OrganizationID uuid.UUID   `json:"organizationId"`
+	LotIDs         []uuid.UUID `json:"lotIds"`
+	Price          float64     `json:"price"`
+	Username       string      `json:"creatorUsername"`
 }
 
 func (a *API) createBid(c echo.Context) error {
@@ -119,6 +133,8 @@ func (a *API) createBid(c echo.Context) error {
 		TenderID:       req.TenderID,
 		CreatorType:    model.CreatorType(req.CreatorType),
 		OrganizationID: req.OrganizationID,
+		LotIDs:         req.LotIDs,
+		Price:          req.Price,
 	}
 
 	b, err := a.service.CreateBid(c.Request().Context(), req.Username, bid)
@@ -126,6 +142,17 @@ func (a *API) createBid(c echo.Context) error {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrNoRights) || errors.Is(err, model.ErrNotInvited) ||
+			errors.Is(err, model.ErrConflictOfInterest) || errors.Is(err, model.ErrBlacklisted) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrBidNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrInvalidPrice) || errors.Is(err, model.ErrLotRequired) ||
+			errors.Is(err, model.ErrLotNotFound) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -157,6 +184,12 @@ func (a *API) updateBid(c echo.Context) error {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrBidNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -180,11 +213,24 @@ func (a *API) updateBidStatus(c echo.Context) error {
 		Status: model.BidStatus(c.QueryParam("status")),
 	}
 
+	if bid.Status == "" {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": model.ErrInvalidBidStatus.Error()})
+	}
+
 	b, err := a.service.UpdateBid(c.Request().Context(), c.QueryParam("username"), bid)
 	if err != nil {
+		if errors.Is(err, model.ErrInvalidBidStatus) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+		}
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrBidNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -209,6 +255,12 @@ func (a *API) rollbackBid(c echo.Context) error {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrBidNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -227,12 +279,33 @@ func (a *API) submitBidDecision(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
 	}
 
-	b, err := a.service.SubmitBidDecision(c.Request().Context(), c.QueryParam("username"), req.BidID,
-		model.BidStatus(c.QueryParam("decision")))
+	var lotID uuid.UUID
+	if c.QueryParam("lotId") != "" {
+		lotID, err = uuid.Parse(c.QueryParam("lotId"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+		}
+	}
+
+	b, err := a.service.SubmitBidDecision(c.Request().Context(), c.QueryParam("username"), req.BidID, lotID,
+		model.BidStatus(c.QueryParam("decision")), c.QueryParam("onBehalfOf"))
 	if err != nil {
+		if errors.Is(err, model.ErrInvalidDecision) || errors.Is(err, model.ErrBidsSealed) ||
+			errors.Is(err, model.ErrAuctionInProgress) || errors.Is(err, model.ErrNotAuctionWinner) ||
+			errors.Is(err, model.ErrLotRequired) || errors.Is(err, model.ErrLotNotFound) ||
+			errors.Is(err, model.ErrStageRejected) || errors.Is(err, model.ErrStagesPassed) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+		}
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrNoRights) || errors.Is(err, model.ErrConflictOfInterest) ||
+			errors.Is(err, model.ErrNotStageApprover) || errors.Is(err, model.ErrNotDelegated) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrBidNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -240,13 +313,20 @@ func (a *API) submitBidDecision(c echo.Context) error {
 }
 
 type bidsResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Status      string    `json:"status"`
-	CreatorType string    `json:"authorType"`
-	CreatorID   uuid.UUID `json:"authorId"`
-	VersionID   int64     `json:"version"`
-	Created     time.Time `json:"createdAt"`
+	ID           uuid.UUID   `json:"id"`
+	Name         string      `json:"name"`
+	Status       string      `json:"status"`
+	CreatorType  string      `json:"authorType"`
+	CreatorID    uuid.UUID   `json:"authorId"`
+	LotIDs       []uuid.UUID `json:"lotIds,omitempty"`
+	Price        float64     `json:"price,omitempty"`
+	VersionID    int64       `json:"version"`
+	Sealed       bool        `json:"sealed,omitempty"`
+	Score        float64     `json:"score,omitempty"`
+	Rank         int         `json:"rank,omitempty"`
+	AuthorRating *float64    `json:"authorRating,omitempty"`
+	Blacklisted  bool        `json:"blacklisted,omitempty"`
+	Created      time.Time   `json:"createdAt"`
 }
 
 func (a *API) bidsFromModel(bids []model.Bid) []bidsResponse {
@@ -259,13 +339,25 @@ func (a *API) bidsFromModel(bids []model.Bid) []bidsResponse {
 }
 
 func (a *API) bidFromModel(bid model.Bid) bidsResponse {
-	return bidsResponse{
+	r := bidsResponse{
 		ID:          bid.ID,
 		Name:        bid.Name,
 		Status:      string(bid.Status),
 		CreatorType: string(bid.CreatorType),
 		CreatorID:   bid.CreatorID,
+		LotIDs:      bid.LotIDs,
+		Price:       bid.Price,
 		VersionID:   bid.VersionID,
+		Sealed:      bid.Sealed,
+		Score:       bid.Score,
+		Rank:        bid.Rank,
+		Blacklisted: bid.Blacklisted,
 		Created:     bid.Created,
 	}
+
+	if bid.AuthorRated {
+		r.AuthorRating = &bid.AuthorRating
+	}
+
+	return r
 }
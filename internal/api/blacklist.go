@@ -0,0 +1,141 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type blacklistRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `param:"organizationId"`
+}
+
+func (a *API) blacklist(c echo.Context) error {
+	var req blacklistRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	entries, err := a.service.Blacklist(c.Request().Context(), req.Username, req.OrganizationID)
+	if err != nil {
+		return a.blacklistError(c, err)
+	}
+
+	r := make([]blacklistEntryResponse, 0, len(entries))
+	for _, l := range entries {
+		r = append(r, a.blacklistEntryFromModel(l))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type createBlacklistEntryRequest struct {
+	OrganizationID        uuid.UUID  `param:"organizationId"`
+	BlockedOrganizationID uuid.UUID  `json:"blockedOrganizationId"`
+	BlockedUsername       string     `json:"blockedUsername"`
+	Reason                string     `json:"reason"`
+	ExpiresAt             *time.Time `json:"expiresAt"`
+}
+
+func (a *API) createBlacklistEntry(c echo.Context) error {
+	var req createBlacklistEntryRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	entry := model.BlacklistEntry{
+		OrganizationID:        req.OrganizationID,
+		BlockedOrganizationID: req.BlockedOrganizationID,
+		Reason:                req.Reason,
+	}
+
+	if req.ExpiresAt != nil {
+		entry.Expires = *req.ExpiresAt
+	}
+
+	l, err := a.service.CreateBlacklistEntry(c.Request().Context(), c.QueryParam("username"), entry,
+		req.BlockedUsername)
+	if err != nil {
+		return a.blacklistError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.blacklistEntryFromModel(l))
+}
+
+type blacklistEntryRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `param:"organizationId"`
+	EntryID        uuid.UUID `param:"entryId"`
+}
+
+func (a *API) deleteBlacklistEntry(c echo.Context) error {
+	var req blacklistEntryRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	err = a.service.DeleteBlacklistEntry(c.Request().Context(), req.Username, req.OrganizationID, req.EntryID)
+	if err != nil {
+		return a.blacklistError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (a *API) blacklistError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrBlacklistEntryNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidBlacklistEntry) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type blacklistEntryResponse struct {
+	ID                    uuid.UUID  `json:"id"`
+	OrganizationID        uuid.UUID  `json:"organizationId"`
+	BlockedOrganizationID *uuid.UUID `json:"blockedOrganizationId,omitempty"`
+	BlockedUsername       string     `json:"blockedUsername,omitempty"`
+	Reason                string     `json:"reason"`
+	ExpiresAt             *time.Time `json:"expiresAt,omitempty"`
+	CreatedAt             time.Time  `json:"createdAt"`
+}
+
+func (a *API) blacklistEntryFromModel(entry model.BlacklistEntry) blacklistEntryResponse {
+	r := blacklistEntryResponse{
+		ID:              entry.ID,
+		OrganizationID:  entry.OrganizationID,
+		BlockedUsername: entry.BlockedUsername,
+		Reason:          entry.Reason,
+		CreatedAt:       entry.Created,
+	}
+
+	if entry.BlockedOrganizationID != uuid.Nil {
+		r.BlockedOrganizationID = &entry.BlockedOrganizationID
+	}
+
+	if !entry.Expires.IsZero() {
+		r.ExpiresAt = &entry.Expires
+	}
+
+	return r
+}
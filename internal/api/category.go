@@ -0,0 +1,138 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+func (a *API) categories(c echo.Context) error {
+	categories, err := a.service.Categories(c.Request().Context())
+	if err != nil {
+		return a.categoryError(c, err)
+	}
+
+	r := make([]categoryResponse, 0, len(categories))
+	for _, category := range categories {
+		r = append(r, a.categoryFromModel(category))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type createCategoryRequest struct {
+	ParentID uuid.UUID `json:"parentId"`
+	Name     string    `json:"name"`
+}
+
+func (a *API) createCategory(c echo.Context) error {
+	var req createCategoryRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	category := model.Category{
+		ParentID: req.ParentID,
+		Name:     req.Name,
+	}
+
+	cat, err := a.service.CreateCategory(c.Request().Context(), c.QueryParam("username"), category)
+	if err != nil {
+		return a.categoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.categoryFromModel(cat))
+}
+
+type updateCategoryRequest struct {
+	CategoryID uuid.UUID `param:"categoryId"`
+	ParentID   uuid.UUID `json:"parentId"`
+	Name       string    `json:"name"`
+}
+
+func (a *API) updateCategory(c echo.Context) error {
+	var req updateCategoryRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	category := model.Category{
+		ID:       req.CategoryID,
+		ParentID: req.ParentID,
+		Name:     req.Name,
+	}
+
+	cat, err := a.service.UpdateCategory(c.Request().Context(), c.QueryParam("username"), category)
+	if err != nil {
+		return a.categoryError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.categoryFromModel(cat))
+}
+
+type categoryRequest struct {
+	Username   string    `query:"username"`
+	CategoryID uuid.UUID `param:"categoryId"`
+}
+
+func (a *API) deleteCategory(c echo.Context) error {
+	var req categoryRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	err = a.service.DeleteCategory(c.Request().Context(), req.Username, req.CategoryID)
+	if err != nil {
+		return a.categoryError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (a *API) categoryError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrCategoryNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidCategory) || errors.Is(err, model.ErrCategoryInUse) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type categoryResponse struct {
+	ID        uuid.UUID  `json:"id"`
+	ParentID  *uuid.UUID `json:"parentId"`
+	Name      string     `json:"name"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+func (a *API) categoryFromModel(category model.Category) categoryResponse {
+	r := categoryResponse{
+		ID:        category.ID,
+		Name:      category.Name,
+		CreatedAt: category.Created,
+	}
+
+	if category.ParentID != uuid.Nil {
+		r.ParentID = &category.ParentID
+	}
+
+	return r
+}
@@ -0,0 +1,81 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type verifyTenderRequest struct {
+	TenderID uuid.UUID `param:"tenderId"`
+	Username string    `query:"username"`
+}
+
+func (a *API) verifyTender(c echo.Context) error {
+	var req verifyTenderRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	v, err := a.service.VerifyTender(c.Request().Context(), req.Username, req.TenderID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrVersionNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, a.chainVerificationFromModel(v))
+}
+
+type verifyBidRequest struct {
+	BidID    uuid.UUID `param:"bidId"`
+	Username string    `query:"username"`
+}
+
+func (a *API) verifyBid(c echo.Context) error {
+	var req verifyBidRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	v, err := a.service.VerifyBid(c.Request().Context(), req.Username, req.BidID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrBidNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, a.chainVerificationFromModel(v))
+}
+
+type chainVerificationResponse struct {
+	Valid    bool   `json:"valid"`
+	Versions int    `json:"versions"`
+	BrokenAt int64  `json:"brokenAtVersion,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+func (a *API) chainVerificationFromModel(v model.ChainVerification) chainVerificationResponse {
+	return chainVerificationResponse{
+		Valid:    v.Valid,
+		Versions: v.Versions,
+		BrokenAt: v.BrokenAt,
+		Reason:   v.Reason,
+	}
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+const streamKeepAlive = 15 * time.Second
+
+type streamRequest struct {
+	Username string    `query:"username"`
+	TenderID uuid.UUID `query:"tenderId"`
+}
+
+func (a *API) stream(c echo.Context) error {
+	var req streamRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	ctx := c.Request().Context()
+
+	changes, err := a.service.Changes(ctx, req.Username, req.TenderID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+	}
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set(echo.HeaderCacheControl, "no-cache")
+	w.Header().Set(echo.HeaderConnection, "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	w.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			_, err = fmt.Fprint(w, ": keep-alive\n\n")
+		case change, ok := <-changes:
+			if !ok {
+				return nil
+			}
+
+			err = a.writeChange(w, change)
+		}
+
+		if err != nil {
+			return nil
+		}
+
+		w.Flush()
+	}
+}
+
+func (a *API) writeChange(w *echo.Response, change model.Change) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s:%d\nevent: %s\ndata: %s\n\n", change.EntityID, change.VersionID, change.EntityType, data)
+
+	return err
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type commentsRequest struct {
+	Username string    `query:"username"`
+	BidID    uuid.UUID `param:"bidId"`
+	Limit    uint64    `query:"limit"`
+	Offset   uint64    `query:"offset"`
+}
+
+func (a *API) comments(c echo.Context) error {
+	var req commentsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	opts := model.CommentFilter{
+		BidID:  req.BidID,
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	}
+
+	comments, err := a.service.Comments(c.Request().Context(), req.Username, opts)
+	if err != nil {
+		return a.commentError(c, err)
+	}
+
+	r := make([]commentResponse, 0, len(comments))
+	for _, cm := range comments {
+		r = append(r, a.commentFromModel(cm))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type createCommentRequest struct {
+	BidID     uuid.UUID `param:"bidId"`
+	Body      string    `json:"body"`
+	ParentID  uuid.UUID `json:"parentId"`
+	VersionID int64     `json:"version"`
+}
+
+func (a *API) createComment(c echo.Context) error {
+	var req createCommentRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	comment := model.Comment{
+		BidID:        req.BidID,
+		BidVersionID: req.VersionID,
+		ParentID:     req.ParentID,
+		Body:         req.Body,
+	}
+
+	cm, err := a.service.CreateComment(c.Request().Context(), c.QueryParam("username"), comment)
+	if err != nil {
+		return a.commentError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.commentFromModel(cm))
+}
+
+type readCommentsRequest struct {
+	BidID uuid.UUID `param:"bidId"`
+}
+
+func (a *API) readComments(c echo.Context) error {
+	var req readCommentsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	err = a.service.MarkCommentsRead(c.Request().Context(), c.QueryParam("username"), req.BidID)
+	if err != nil {
+		return a.commentError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (a *API) commentError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrTenderOrBidNotFound) || errors.Is(err, model.ErrCommentNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidComment) || errors.Is(err, model.ErrBidsSealed) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type commentResponse struct {
+	ID        uuid.UUID   `json:"id"`
+	BidID     uuid.UUID   `json:"bidId"`
+	VersionID int64       `json:"version"`
+	ParentID  *uuid.UUID  `json:"parentId,omitempty"`
+	AuthorID  uuid.UUID   `json:"authorId"`
+	Body      string      `json:"body"`
+	Mentions  []uuid.UUID `json:"mentions,omitempty"`
+	Read      bool        `json:"read"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+func (a *API) commentFromModel(comment model.Comment) commentResponse {
+	r := commentResponse{
+		ID:        comment.ID,
+		BidID:     comment.BidID,
+		VersionID: comment.BidVersionID,
+		AuthorID:  comment.AuthorID,
+		Body:      comment.Body,
+		Mentions:  comment.Mentions,
+		Read:      comment.Read,
+		CreatedAt: comment.Created,
+	}
+
+	if comment.ParentID != uuid.Nil {
+		r.ParentID = &comment.ParentID
+	}
+
+	return r
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/xuri/excelize/v2"
+
+	"zadanie-6105/internal/model"
+)
+
+const (
+	comparisonJSON = "json"
+	comparisonCSV  = "csv"
+	comparisonXLSX = "xlsx"
+)
+
+type tenderComparisonRequest struct {
+	TenderID uuid.UUID `param:"tenderId"`
+	Username string    `query:"username"`
+	Format   string    `query:"format"`
+}
+
+func (a *API) tenderComparison(c echo.Context) error {
+	var req tenderComparisonRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	if req.Format == "" {
+		req.Format = comparisonJSON
+	}
+
+	if req.Format != comparisonJSON && req.Format != comparisonCSV && req.Format != comparisonXLSX {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "format must be json, csv or xlsx"})
+	}
+
+	comparison, err := a.service.TenderComparison(c.Request().Context(), req.Username, req.TenderID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrVersionNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+	}
+
+	switch req.Format {
+	case comparisonCSV:
+		return a.comparisonCSV(c, comparison)
+	case comparisonXLSX:
+		return a.comparisonXLSX(c, comparison)
+	default:
+		return c.JSON(http.StatusOK, a.comparisonFromModel(comparison))
+	}
+}
+
+func (a *API) comparisonCSV(c echo.Context, comparison model.Comparison) error {
+	header, rows := a.comparisonTable(comparison)
+
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, comparisonAttachment(comparison, comparisonCSV))
+	c.Response().WriteHeader(http.StatusOK)
+
+	w := csv.NewWriter(c.Response())
+
+	record := make([]string, 0, len(header))
+	for _, v := range header {
+		record = append(record, comparisonCell(v))
+	}
+
+	err := w.Write(record)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, row := range rows {
+		record = make([]string, 0, len(row))
+		for _, v := range row {
+			record = append(record, comparisonCell(v))
+		}
+
+		err = w.Write(record)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	w.Flush()
+
+	return errors.WithStack(w.Error())
+}
+
+func (a *API) comparisonXLSX(c echo.Context, comparison model.Comparison) error {
+	header, rows := a.comparisonTable(comparison)
+
+	f := excelize.NewFile()
+	defer func() { _ = f.Close() }()
+
+	sheet := f.GetSheetName(0)
+
+	err := f.SetSheetRow(sheet, "A1", &header)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+	}
+
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+		}
+
+		err = f.SetSheetRow(sheet, cell, &row)
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+		}
+	}
+
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+	}
+
+	c.Response().Header().Set(echo.HeaderContentDisposition, comparisonAttachment(comparison, comparisonXLSX))
+
+	return c.Blob(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", buf.Bytes())
+}
+
+// comparisonTable flattens a comparison for the spreadsheet formats, one column per criterion after the fixed ones.
+func (a *API) comparisonTable(comparison model.Comparison) ([]string, [][]any) {
+	header := []string{"Name", "Description", "Price", "Author type", "Version", "Approvals", "Rejections",
+		"Score", "Rank"}
+	for _, cr := range comparison.Criteria {
+		header = append(header, cr.Name)
+	}
+
+	rows := make([][]any, 0, len(comparison.Rows))
+	for _, r := range comparison.Rows {
+		row := []any{r.Bid.Name, r.Bid.Description, r.Bid.Price, string(r.Bid.CreatorType), r.Bid.VersionID,
+			r.Approvals, r.Rejections, r.Bid.Score, r.Bid.Rank}
+		for _, cr := range comparison.Criteria {
+			row = append(row, r.Scores[cr.ID])
+		}
+
+		rows = append(rows, row)
+	}
+
+	return header, rows
+}
+
+// comparisonCell formats a value for CSV. Text that starts like a formula gets a leading quote, so spreadsheets
+// show what a bidder wrote instead of evaluating it.
+func comparisonCell(v any) string {
+	switch v := v.(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		if v != "" && strings.ContainsRune("=+-@\t\r", rune(v[0])) {
+			return "'" + v
+		}
+
+		return v
+	}
+
+	return fmt.Sprint(v)
+}
+
+func comparisonAttachment(comparison model.Comparison, format string) string {
+	return fmt.Sprintf(`attachment; filename="comparison-%s.%s"`, comparison.Tender.ID, format)
+}
+
+type comparisonResponse struct {
+	TenderID   uuid.UUID               `json:"tenderId"`
+	TenderName string                  `json:"tenderName"`
+	Criteria   []criterionResponse     `json:"criteria"`
+	Bids       []comparisonBidResponse `json:"bids"`
+}
+
+type comparisonBidResponse struct {
+	ID          uuid.UUID             `json:"id"`
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Price       float64               `json:"price,omitempty"`
+	CreatorType string                `json:"authorType"`
+	VersionID   int64                 `json:"version"`
+	Approvals   int                   `json:"approvals"`
+	Rejections  int                   `json:"rejections"`
+	Score       float64               `json:"score,omitempty"`
+	Rank        int                   `json:"rank,omitempty"`
+	Scores      map[uuid.UUID]float64 `json:"scores,omitempty"`
+	Sealed      bool                  `json:"sealed,omitempty"`
+}
+
+func (a *API) comparisonFromModel(comparison model.Comparison) comparisonResponse {
+	r := comparisonResponse{
+		TenderID:   comparison.Tender.ID,
+		TenderName: comparison.Tender.Name,
+		Criteria:   a.criteriaFromModel(comparison.Criteria),
+		Bids:       make([]comparisonBidResponse, 0, len(comparison.Rows)),
+	}
+
+	for _, row := range comparison.Rows {
+		r.Bids = append(r.Bids, comparisonBidResponse{
+			ID:          row.Bid.ID,
+			Name:        row.Bid.Name,
+			Description: row.Bid.Description,
+			Price:       row.Bid.Price,
+			CreatorType: string(row.Bid.CreatorType),
+			VersionID:   row.Bid.VersionID,
+			Approvals:   row.Approvals,
+			Rejections:  row.Rejections,
+			Score:       row.Bid.Score,
+			Rank:        row.Bid.Rank,
+			Scores:      row.Scores,
+			Sealed:      row.Bid.Sealed,
+		})
+	}
+
+	return r
+}
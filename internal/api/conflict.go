@@ -0,0 +1,122 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type conflictExemptionsRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `param:"organizationId"`
+}
+
+func (a *API) conflictExemptions(c echo.Context) error {
+	var req conflictExemptionsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	exemptions, err := a.service.ConflictExemptions(c.Request().Context(), req.Username, req.OrganizationID)
+	if err != nil {
+		return a.exemptionError(c, err)
+	}
+
+	r := make([]exemptionResponse, 0, len(exemptions))
+	for _, x := range exemptions {
+		r = append(r, a.exemptionFromModel(x))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type createConflictExemptionRequest struct {
+	OrganizationID uuid.UUID `param:"organizationId"`
+	Username       string    `json:"username"`
+	Reason         string    `json:"reason"`
+}
+
+func (a *API) createConflictExemption(c echo.Context) error {
+	var req createConflictExemptionRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	exemption := model.ConflictExemption{
+		OrganizationID: req.OrganizationID,
+		Reason:         req.Reason,
+	}
+
+	x, err := a.service.CreateConflictExemption(c.Request().Context(), c.QueryParam("username"), exemption,
+		req.Username)
+	if err != nil {
+		return a.exemptionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.exemptionFromModel(x))
+}
+
+type conflictExemptionRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `param:"organizationId"`
+	ExemptionID    uuid.UUID `param:"exemptionId"`
+}
+
+func (a *API) deleteConflictExemption(c echo.Context) error {
+	var req conflictExemptionRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	err = a.service.DeleteConflictExemption(c.Request().Context(), req.Username, req.OrganizationID, req.ExemptionID)
+	if err != nil {
+		return a.exemptionError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (a *API) exemptionError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrExemptionNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidExemption) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type exemptionResponse struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organizationId"`
+	Username       string    `json:"username"`
+	Reason         string    `json:"reason"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func (a *API) exemptionFromModel(exemption model.ConflictExemption) exemptionResponse {
+	return exemptionResponse{
+		ID:             exemption.ID,
+		OrganizationID: exemption.OrganizationID,
+		Username:       exemption.Username,
+		Reason:         exemption.Reason,
+		CreatedAt:      exemption.Created,
+	}
+}
@@ -0,0 +1,183 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type criteriaRequest struct {
+	Username string    `query:"username"`
+	TenderID uuid.UUID `param:"tenderId"`
+}
+
+func (a *API) criteria(c echo.Context) error {
+	var req criteriaRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	criteria, err := a.service.Criteria(c.Request().Context(), req.Username, req.TenderID)
+	if err != nil {
+		return a.criterionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.criteriaFromModel(criteria))
+}
+
+type replaceCriteriaRequest struct {
+	TenderID uuid.UUID `param:"tenderId"`
+	Criteria []struct {
+		Name   string  `json:"name"`
+		Weight float64 `json:"weight"`
+	} `json:"criteria"`
+}
+
+func (a *API) replaceCriteria(c echo.Context) error {
+	var req replaceCriteriaRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	criteria := make([]model.Criterion, 0, len(req.Criteria))
+	for _, cr := range req.Criteria {
+		criteria = append(criteria, model.Criterion{
+			TenderID: req.TenderID,
+			Name:     cr.Name,
+			Weight:   cr.Weight,
+		})
+	}
+
+	criteria, err = a.service.ReplaceCriteria(c.Request().Context(), c.QueryParam("username"), req.TenderID, criteria)
+	if err != nil {
+		return a.criterionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.criteriaFromModel(criteria))
+}
+
+type bidScoresRequest struct {
+	Username string    `query:"username"`
+	BidID    uuid.UUID `param:"bidId"`
+}
+
+func (a *API) bidScores(c echo.Context) error {
+	var req bidScoresRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	scores, err := a.service.BidScores(c.Request().Context(), req.Username, req.BidID)
+	if err != nil {
+		return a.criterionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.scoresFromModel(scores))
+}
+
+type scoreBidRequest struct {
+	BidID  uuid.UUID `param:"bidId"`
+	Scores []struct {
+		CriterionID uuid.UUID `json:"criterionId"`
+		Score       float64   `json:"score"`
+	} `json:"scores"`
+}
+
+func (a *API) scoreBid(c echo.Context) error {
+	var req scoreBidRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	scores := make([]model.Score, 0, len(req.Scores))
+	for _, sc := range req.Scores {
+		scores = append(scores, model.Score{
+			BidID:       req.BidID,
+			CriterionID: sc.CriterionID,
+			Score:       sc.Score,
+		})
+	}
+
+	scores, err = a.service.ScoreBid(c.Request().Context(), c.QueryParam("username"), req.BidID, scores)
+	if err != nil {
+		return a.criterionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.scoresFromModel(scores))
+}
+
+func (a *API) criterionError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrTenderOrVersionNotFound) || errors.Is(err, model.ErrTenderOrBidNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidCriteria) || errors.Is(err, model.ErrCriteriaScored) ||
+		errors.Is(err, model.ErrInvalidScore) || errors.Is(err, model.ErrBidsSealed) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type criterionResponse struct {
+	ID        uuid.UUID `json:"id"`
+	TenderID  uuid.UUID `json:"tenderId"`
+	Name      string    `json:"name"`
+	Weight    float64   `json:"weight"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func (a *API) criteriaFromModel(criteria []model.Criterion) []criterionResponse {
+	r := make([]criterionResponse, 0, len(criteria))
+	for _, cr := range criteria {
+		r = append(r, criterionResponse{
+			ID:        cr.ID,
+			TenderID:  cr.TenderID,
+			Name:      cr.Name,
+			Weight:    cr.Weight,
+			CreatedAt: cr.Created,
+		})
+	}
+
+	return r
+}
+
+type scoreResponse struct {
+	BidID       uuid.UUID `json:"bidId"`
+	CriterionID uuid.UUID `json:"criterionId"`
+	EmployeeID  uuid.UUID `json:"employeeId"`
+	Score       float64   `json:"score"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+func (a *API) scoresFromModel(scores []model.Score) []scoreResponse {
+	r := make([]scoreResponse, 0, len(scores))
+	for _, sc := range scores {
+		r = append(r, scoreResponse{
+			BidID:       sc.BidID,
+			CriterionID: sc.CriterionID,
+			EmployeeID:  sc.EmployeeID,
+			Score:       sc.Score,
+			UpdatedAt:   sc.Updated,
+		})
+	}
+
+	return r
+}
@@ -0,0 +1,135 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type delegationsRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `param:"organizationId"`
+}
+
+func (a *API) delegations(c echo.Context) error {
+	var req delegationsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	delegations, err := a.service.Delegations(c.Request().Context(), req.Username, req.OrganizationID)
+	if err != nil {
+		return a.delegationError(c, err)
+	}
+
+	r := make([]delegationResponse, 0, len(delegations))
+	for _, d := range delegations {
+		r = append(r, a.delegationFromModel(d))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type createDelegationRequest struct {
+	OrganizationID uuid.UUID  `param:"organizationId"`
+	Delegator      string     `json:"delegator"`
+	Delegate       string     `json:"delegate"`
+	StartsAt       *time.Time `json:"startsAt"`
+	EndsAt         *time.Time `json:"endsAt"`
+}
+
+func (a *API) createDelegation(c echo.Context) error {
+	var req createDelegationRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	delegation := model.Delegation{
+		OrganizationID: req.OrganizationID,
+	}
+
+	if req.StartsAt != nil {
+		delegation.Starts = *req.StartsAt
+	}
+
+	if req.EndsAt != nil {
+		delegation.Ends = *req.EndsAt
+	}
+
+	d, err := a.service.CreateDelegation(c.Request().Context(), c.QueryParam("username"), delegation, req.Delegator,
+		req.Delegate)
+	if err != nil {
+		return a.delegationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.delegationFromModel(d))
+}
+
+type delegationRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `param:"organizationId"`
+	DelegationID   uuid.UUID `param:"delegationId"`
+}
+
+func (a *API) deleteDelegation(c echo.Context) error {
+	var req delegationRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	err = a.service.DeleteDelegation(c.Request().Context(), req.Username, req.OrganizationID, req.DelegationID)
+	if err != nil {
+		return a.delegationError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (a *API) delegationError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrDelegationNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidDelegation) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type delegationResponse struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organizationId"`
+	Delegator      string    `json:"delegator"`
+	Delegate       string    `json:"delegate"`
+	StartsAt       time.Time `json:"startsAt"`
+	EndsAt         time.Time `json:"endsAt"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func (a *API) delegationFromModel(delegation model.Delegation) delegationResponse {
+	return delegationResponse{
+		ID:             delegation.ID,
+		OrganizationID: delegation.OrganizationID,
+		Delegator:      delegation.Delegator,
+		Delegate:       delegation.Delegate,
+		StartsAt:       delegation.Starts,
+		EndsAt:         delegation.Ends,
+		CreatedAt:      delegation.Created,
+	}
+}
@@ -0,0 +1,196 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type invitationsRequest struct {
+	Username string    `query:"username"`
+	TenderID uuid.UUID `param:"tenderId"`
+	Status   string    `query:"status"`
+	Limit    uint64    `query:"limit"`
+	Offset   uint64    `query:"offset"`
+}
+
+func (a *API) invitations(c echo.Context) error {
+	var req invitationsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	invitations, err := a.service.Invitations(c.Request().Context(), req.Username, a.invitationFilter(req))
+	if err != nil {
+		return a.invitationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.invitationsFromModel(invitations))
+}
+
+func (a *API) myInvitations(c echo.Context) error {
+	var req invitationsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	invitations, err := a.service.MyInvitations(c.Request().Context(), req.Username, a.invitationFilter(req))
+	if err != nil {
+		return a.invitationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.invitationsFromModel(invitations))
+}
+
+func (a *API) invitationFilter(req invitationsRequest) model.InvitationFilter {
+	opts := model.InvitationFilter{
+		TenderID: req.TenderID,
+		Offset:   req.Offset,
+		Limit:    req.Limit,
+	}
+
+	if req.Status != "" {
+		opts.Status = []model.InvitationStatus{model.InvitationStatus(req.Status)}
+	}
+
+	return opts
+}
+
+type createInvitationRequest struct {
+	TenderID       uuid.UUID `param:"tenderId"`
+	OrganizationID uuid.UUID `json:"organizationId"`
+	Username       string    `json:"username"`
+}
+
+func (a *API) createInvitation(c echo.Context) error {
+	var req createInvitationRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	invitation := model.Invitation{
+		TenderID:       req.TenderID,
+		OrganizationID: req.OrganizationID,
+	}
+
+	i, err := a.service.CreateInvitation(c.Request().Context(), c.QueryParam("username"), invitation, req.Username)
+	if err != nil {
+		return a.invitationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.invitationFromModel(i))
+}
+
+type deleteInvitationRequest struct {
+	Username     string    `query:"username"`
+	TenderID     uuid.UUID `param:"tenderId"`
+	InvitationID uuid.UUID `param:"invitationId"`
+}
+
+func (a *API) deleteInvitation(c echo.Context) error {
+	var req deleteInvitationRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	err = a.service.DeleteInvitation(c.Request().Context(), req.Username, req.TenderID, req.InvitationID)
+	if err != nil {
+		return a.invitationError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+type respondInvitationRequest struct {
+	InvitationID uuid.UUID `param:"invitationId"`
+}
+
+func (a *API) respondInvitation(c echo.Context) error {
+	var req respondInvitationRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	i, err := a.service.RespondInvitation(c.Request().Context(), c.QueryParam("username"), req.InvitationID,
+		model.InvitationStatus(c.QueryParam("decision")))
+	if err != nil {
+		return a.invitationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.invitationFromModel(i))
+}
+
+func (a *API) invitationError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrTenderOrVersionNotFound) || errors.Is(err, model.ErrInvitationNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidInvitation) || errors.Is(err, model.ErrInvalidDecision) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type invitationResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	TenderID       uuid.UUID  `json:"tenderId"`
+	OrganizationID *uuid.UUID `json:"organizationId,omitempty"`
+	EmployeeID     *uuid.UUID `json:"userId,omitempty"`
+	Status         string     `json:"status"`
+	InviterID      uuid.UUID  `json:"inviterId"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	RespondedAt    *time.Time `json:"respondedAt,omitempty"`
+}
+
+func (a *API) invitationsFromModel(invitations []model.Invitation) []invitationResponse {
+	r := make([]invitationResponse, 0, len(invitations))
+	for _, i := range invitations {
+		r = append(r, a.invitationFromModel(i))
+	}
+
+	return r
+}
+
+func (a *API) invitationFromModel(invitation model.Invitation) invitationResponse {
+	r := invitationResponse{
+		ID:        invitation.ID,
+		TenderID:  invitation.TenderID,
+		Status:    string(invitation.Status),
+		InviterID: invitation.InviterID,
+		CreatedAt: invitation.Created,
+	}
+
+	if invitation.OrganizationID != uuid.Nil {
+		r.OrganizationID = &invitation.OrganizationID
+	}
+
+	if invitation.EmployeeID != uuid.Nil {
+		r.EmployeeID = &invitation.EmployeeID
+	}
+
+	if !invitation.Responded.IsZero() {
+		r.RespondedAt = &invitation.Responded
+	}
+
+	return r
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type lotsRequest struct {
+	Username string    `query:"username"`
+	TenderID uuid.UUID `param:"tenderId"`
+}
+
+func (a *API) lots(c echo.Context) error {
+	var req lotsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	lots, err := a.service.Lots(c.Request().Context(), req.Username, req.TenderID)
+	if err != nil {
+		return a.lotError(c, err)
+	}
+
+	r := make([]lotResponse, 0, len(lots))
+	for _, l := range lots {
+		r = append(r, a.lotFromModel(l))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type lotRequest struct {
+	TenderID    uuid.UUID `param:"tenderId"`
+	LotID       uuid.UUID `param:"lotId"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+}
+
+func (a *API) createLot(c echo.Context) error {
+	var req lotRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	lot := model.Lot{
+		TenderID:    req.TenderID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	l, err := a.service.CreateLot(c.Request().Context(), c.QueryParam("username"), lot)
+	if err != nil {
+		return a.lotError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.lotFromModel(l))
+}
+
+func (a *API) updateLot(c echo.Context) error {
+	var req lotRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	lot := model.Lot{
+		ID:          req.LotID,
+		TenderID:    req.TenderID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+
+	l, err := a.service.UpdateLot(c.Request().Context(), c.QueryParam("username"), lot)
+	if err != nil {
+		return a.lotError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.lotFromModel(l))
+}
+
+type lotStatusRequest struct {
+	Username string    `query:"username"`
+	TenderID uuid.UUID `param:"tenderId"`
+	LotID    uuid.UUID `param:"lotId"`
+}
+
+func (a *API) removeLot(c echo.Context) error {
+	var req lotStatusRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	l, err := a.service.RemoveLot(c.Request().Context(), req.Username, req.TenderID, req.LotID)
+	if err != nil {
+		return a.lotError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.lotFromModel(l))
+}
+
+func (a *API) cancelLot(c echo.Context) error {
+	var req lotStatusRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	l, err := a.service.CancelLot(c.Request().Context(), c.QueryParam("username"), req.TenderID, req.LotID)
+	if err != nil {
+		return a.lotError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.lotFromModel(l))
+}
+
+func (a *API) lotError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrTenderOrVersionNotFound) || errors.Is(err, model.ErrLotNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidLot) || errors.Is(err, model.ErrLotHasBids) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type lotResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	TenderID     uuid.UUID  `json:"tenderId"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Status       string     `json:"status"`
+	AwardedBidID *uuid.UUID `json:"awardedBidId,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+func (a *API) lotFromModel(lot model.Lot) lotResponse {
+	r := lotResponse{
+		ID:          lot.ID,
+		TenderID:    lot.TenderID,
+		Name:        lot.Name,
+		Description: lot.Description,
+		Status:      string(lot.Status),
+		CreatedAt:   lot.Created,
+	}
+
+	if lot.AwardedBidID != uuid.Nil {
+		r.AwardedBidID = &lot.AwardedBidID
+	}
+
+	return r
+}
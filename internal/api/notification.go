@@ -0,0 +1,184 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type notificationsRequest struct {
+	Username string `query:"username"`
+	Unread   bool   `query:"unread"`
+	Limit    uint64 `query:"limit"`
+	Offset   uint64 `query:"offset"`
+}
+
+func (a *API) notifications(c echo.Context) error {
+	var req notificationsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	opts := model.NotificationFilter{
+		Unread: req.Unread,
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	}
+
+	notifications, err := a.service.Notifications(c.Request().Context(), req.Username, opts)
+	if err != nil {
+		return a.notificationError(c, err)
+	}
+
+	r := make([]notificationResponse, 0, len(notifications))
+	for _, n := range notifications {
+		r = append(r, a.notificationFromModel(n))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type readNotificationRequest struct {
+	NotificationID int64 `param:"notificationId"`
+}
+
+func (a *API) readNotification(c echo.Context) error {
+	var req readNotificationRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	n, err := a.service.MarkNotificationRead(c.Request().Context(), c.QueryParam("username"), req.NotificationID)
+	if err != nil {
+		return a.notificationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.notificationFromModel(n))
+}
+
+func (a *API) readNotifications(c echo.Context) error {
+	err := a.service.MarkNotificationsRead(c.Request().Context(), c.QueryParam("username"))
+	if err != nil {
+		return a.notificationError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+type notificationPreferencesRequest struct {
+	Username string `query:"username"`
+}
+
+func (a *API) notificationPreferences(c echo.Context) error {
+	var req notificationPreferencesRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	preferences, err := a.service.NotificationPreferences(c.Request().Context(), req.Username)
+	if err != nil {
+		return a.notificationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.notificationPreferencesFromModel(preferences))
+}
+
+type notificationPreference struct {
+	EventType string `json:"eventType"`
+	InApp     bool   `json:"inApp"`
+	Email     bool   `json:"email"`
+}
+
+func (a *API) updateNotificationPreferences(c echo.Context) error {
+	var req []notificationPreference
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	preferences := make([]model.NotificationPreference, 0, len(req))
+	for _, p := range req {
+		preferences = append(preferences, model.NotificationPreference{
+			EventType: model.EventType(p.EventType),
+			InApp:     p.InApp,
+			Email:     p.Email,
+		})
+	}
+
+	preferences, err = a.service.UpdateNotificationPreferences(c.Request().Context(), c.QueryParam("username"),
+		preferences)
+	if err != nil {
+		return a.notificationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.notificationPreferencesFromModel(preferences))
+}
+
+func (a *API) notificationError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNotificationNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidNotificationPreference) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type notificationResponse struct {
+	ID         int64      `json:"id"`
+	EventType  string     `json:"eventType"`
+	EntityType string     `json:"entityType"`
+	EntityID   uuid.UUID  `json:"entityId"`
+	Subject    string     `json:"subject"`
+	Body       string     `json:"body"`
+	Read       bool       `json:"read"`
+	ReadAt     *time.Time `json:"readAt,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+func (a *API) notificationFromModel(n model.Notification) notificationResponse {
+	r := notificationResponse{
+		ID:         n.ID,
+		EventType:  string(n.EventType),
+		EntityType: string(n.EntityType),
+		EntityID:   n.EntityID,
+		Subject:    n.Subject,
+		Body:       n.Body,
+		Read:       !n.Read.IsZero(),
+		CreatedAt:  n.Created,
+	}
+
+	if !n.Read.IsZero() {
+		r.ReadAt = &n.Read
+	}
+
+	return r
+}
+
+func (a *API) notificationPreferencesFromModel(preferences []model.NotificationPreference) []notificationPreference {
+	r := make([]notificationPreference, 0, len(preferences))
+	for _, p := range preferences {
+		r = append(r, notificationPreference{
+			EventType: string(p.EventType),
+			InApp:     p.InApp,
+			Email:     p.Email,
+		})
+	}
+
+	return r
+}
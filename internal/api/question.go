@@ -0,0 +1,169 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type questionsRequest struct {
+	Username string    `query:"username"`
+	TenderID uuid.UUID `param:"tenderId"`
+	Limit    uint64    `query:"limit"`
+	Offset   uint64    `query:"offset"`
+}
+
+func (a *API) questions(c echo.Context) error {
+	var req questionsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	opts := model.QuestionFilter{
+		TenderID: req.TenderID,
+		Offset:   req.Offset,
+		Limit:    req.Limit,
+	}
+
+	questions, err := a.service.Questions(c.Request().Context(), req.Username, opts)
+	if err != nil {
+		return a.questionError(c, err)
+	}
+
+	r := make([]questionResponse, 0, len(questions))
+	for _, q := range questions {
+		r = append(r, a.questionFromModel(q))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type askQuestionRequest struct {
+	TenderID uuid.UUID `param:"tenderId"`
+	Question string    `json:"question"`
+}
+
+func (a *API) askQuestion(c echo.Context) error {
+	var req askQuestionRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	question := model.Question{
+		TenderID: req.TenderID,
+		Question: req.Question,
+	}
+
+	q, err := a.service.AskQuestion(c.Request().Context(), c.QueryParam("username"), question)
+	if err != nil {
+		return a.questionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.questionFromModel(q))
+}
+
+type answerQuestionRequest struct {
+	TenderID   uuid.UUID `param:"tenderId"`
+	QuestionID uuid.UUID `param:"questionId"`
+	Answer     string    `json:"answer"`
+	Visibility string    `json:"visibility"`
+	// Tender optionally edits the tender together with the answer.
+	Tender struct {
+		Name        string    `json:"name"`
+		Description string    `json:"description"`
+		CategoryID  uuid.UUID `json:"categoryId"`
+		ServiceType string    `json:"serviceType"`
+	} `json:"tender"`
+}
+
+func (a *API) answerQuestion(c echo.Context) error {
+	var req answerQuestionRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	answer := model.Question{
+		ID:         req.QuestionID,
+		TenderID:   req.TenderID,
+		Answer:     req.Answer,
+		Visibility: model.AnswerVisibility(req.Visibility),
+	}
+
+	change := model.Tender{
+		Name:        req.Tender.Name,
+		Description: req.Tender.Description,
+		CategoryID:  req.Tender.CategoryID,
+		Category:    req.Tender.ServiceType,
+	}
+
+	q, err := a.service.AnswerQuestion(c.Request().Context(), c.QueryParam("username"), answer, change)
+	if err != nil {
+		return a.questionError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.questionFromModel(q))
+}
+
+func (a *API) questionError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrTenderOrVersionNotFound) || errors.Is(err, model.ErrQuestionNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidQuestion) || errors.Is(err, model.ErrQuestionsClosed) ||
+		errors.Is(err, model.ErrInvalidAnswer) || errors.Is(err, model.ErrCategoryNotFound) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type questionResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	TenderID      uuid.UUID  `json:"tenderId"`
+	AuthorID      uuid.UUID  `json:"authorId"`
+	Question      string     `json:"question"`
+	Answer        string     `json:"answer,omitempty"`
+	Visibility    string     `json:"visibility,omitempty"`
+	AnswererID    *uuid.UUID `json:"answeredBy,omitempty"`
+	TenderVersion int64      `json:"tenderVersion,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	AnsweredAt    *time.Time `json:"answeredAt,omitempty"`
+}
+
+func (a *API) questionFromModel(question model.Question) questionResponse {
+	r := questionResponse{
+		ID:            question.ID,
+		TenderID:      question.TenderID,
+		AuthorID:      question.AuthorID,
+		Question:      question.Question,
+		Answer:        question.Answer,
+		Visibility:    string(question.Visibility),
+		TenderVersion: question.TenderVersionID,
+		CreatedAt:     question.Created,
+	}
+
+	if question.AnswererID != uuid.Nil {
+		r.AnswererID = &question.AnswererID
+	}
+
+	if !question.Answered.IsZero() {
+		r.AnsweredAt = &question.Answered
+	}
+
+	return r
+}
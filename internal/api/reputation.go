@@ -0,0 +1,199 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type bidFeedbackRequest struct {
+	BidID uuid.UUID `param:"bidId"`
+}
+
+func (a *API) submitBidFeedback(c echo.Context) error {
+	var req bidFeedbackRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	var rating int
+	if c.QueryParam("rating") != "" {
+		rating, err = strconv.Atoi(c.QueryParam("rating"))
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+		}
+	}
+
+	b, err := a.service.SubmitBidFeedback(c.Request().Context(), c.QueryParam("username"), req.BidID,
+		c.QueryParam("bidFeedback"), rating)
+	if err != nil {
+		return a.reputationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.bidFromModel(b))
+}
+
+func (a *API) confirmDelivery(c echo.Context) error {
+	var req bidFeedbackRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	onTime, err := strconv.ParseBool(c.QueryParam("onTime"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	b, err := a.service.ConfirmDelivery(c.Request().Context(), c.QueryParam("username"), req.BidID, onTime)
+	if err != nil {
+		return a.reputationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.bidFromModel(b))
+}
+
+type bidReviewsRequest struct {
+	TenderID  uuid.UUID `param:"tenderId"`
+	Author    string    `query:"authorUsername"`
+	Requester string    `query:"requesterUsername"`
+	Limit     uint64    `query:"limit"`
+	Offset    uint64    `query:"offset"`
+}
+
+func (a *API) bidReviews(c echo.Context) error {
+	var req bidReviewsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	opts := model.ReviewFilter{
+		Offset: req.Offset,
+		Limit:  req.Limit,
+	}
+
+	reviews, err := a.service.BidReviews(c.Request().Context(), req.Requester, req.TenderID, req.Author, opts)
+	if err != nil {
+		return a.reputationError(c, err)
+	}
+
+	r := make([]reviewResponse, 0, len(reviews))
+	for _, review := range reviews {
+		r = append(r, a.reviewFromModel(review))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type organizationReputationRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `param:"organizationId"`
+}
+
+func (a *API) organizationReputation(c echo.Context) error {
+	var req organizationReputationRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	reputation, err := a.service.OrganizationReputation(c.Request().Context(), req.Username, req.OrganizationID)
+	if err != nil {
+		return a.reputationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.reputationFromModel(reputation))
+}
+
+func (a *API) reputationError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrTenderOrBidNotFound) || errors.Is(err, model.ErrTenderOrVersionNotFound) ||
+		errors.Is(err, model.ErrOrganizationNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidFeedback) || errors.Is(err, model.ErrInvalidDelivery) ||
+		errors.Is(err, model.ErrBidsSealed) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type reviewResponse struct {
+	ID          uuid.UUID `json:"id"`
+	BidID       uuid.UUID `json:"bidId"`
+	Description string    `json:"description"`
+	Rating      int       `json:"rating,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func (a *API) reviewFromModel(review model.Review) reviewResponse {
+	return reviewResponse{
+		ID:          review.ID,
+		BidID:       review.BidID,
+		Description: review.Description,
+		Rating:      review.Rating,
+		CreatedAt:   review.Created,
+	}
+}
+
+type reputationResponse struct {
+	OrganizationID  uuid.UUID  `json:"organizationId"`
+	Rating          *float64   `json:"rating"`
+	Bids            int        `json:"bids"`
+	ApprovalRatio   *float64   `json:"approvalRatio"`
+	WithdrawalRatio *float64   `json:"withdrawalRatio"`
+	Reviews         int        `json:"reviews"`
+	AverageReview   *float64   `json:"averageReview"`
+	Deliveries      int        `json:"deliveries"`
+	OnTimeRatio     *float64   `json:"onTimeRatio"`
+	ComputedAt      *time.Time `json:"computedAt,omitempty"`
+}
+
+func (a *API) reputationFromModel(reputation model.Reputation) reputationResponse {
+	ratio := func(n, total int) *float64 {
+		if total == 0 {
+			return nil
+		}
+
+		f := float64(n) / float64(total)
+		return &f
+	}
+
+	r := reputationResponse{
+		OrganizationID:  reputation.SubjectID,
+		Bids:            reputation.Bids,
+		ApprovalRatio:   ratio(reputation.Approved, reputation.Bids),
+		WithdrawalRatio: ratio(reputation.Withdrawn, reputation.Bids),
+		Reviews:         reputation.Reviews,
+		AverageReview:   ratio(reputation.RatingSum, reputation.Reviews),
+		Deliveries:      reputation.Deliveries,
+		OnTimeRatio:     ratio(reputation.OnTime, reputation.Deliveries),
+	}
+
+	if reputation.Rated {
+		r.Rating = &reputation.Rating
+	}
+
+	if !reputation.Computed.IsZero() {
+		r.ComputedAt = &reputation.Computed
+	}
+
+	return r
+}
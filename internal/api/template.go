@@ -0,0 +1,172 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type tenderTemplatesRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `query:"organizationId"`
+}
+
+func (a *API) tenderTemplates(c echo.Context) error {
+	var req tenderTemplatesRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	templates, err := a.service.TenderTemplates(c.Request().Context(), req.Username, req.OrganizationID)
+	if err != nil {
+		return a.templateError(c, err)
+	}
+
+	r := make([]tenderTemplateResponse, 0, len(templates))
+	for _, t := range templates {
+		r = append(r, a.templateFromModel(t))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type createTenderTemplateRequest struct {
+	OrganizationID uuid.UUID `json:"organizationId"`
+	Name           string    `json:"name"`
+	TenderName     string    `json:"tenderName"`
+	Description    string    `json:"description"`
+	CategoryID     uuid.UUID `json:"categoryId"`
+	ServiceType    string    `json:"serviceType"`
+	Visibility     string    `json:"visibility"`
+	BidMode        string    `json:"bidMode"`
+}
+
+func (a *API) createTenderTemplate(c echo.Context) error {
+	var req createTenderTemplateRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	template := model.TenderTemplate{
+		OrganizationID: req.OrganizationID,
+		Name:           req.Name,
+		TenderName:     req.TenderName,
+		Description:    req.Description,
+		CategoryID:     req.CategoryID,
+		Category:       req.ServiceType,
+		Visibility:     model.TenderVisibility(req.Visibility),
+		BidMode:        model.TenderBidMode(req.BidMode),
+	}
+
+	t, err := a.service.CreateTenderTemplate(c.Request().Context(), c.QueryParam("username"), template)
+	if err != nil {
+		return a.templateError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.templateFromModel(t))
+}
+
+type tenderTemplateRequest struct {
+	Username   string    `query:"username"`
+	TemplateID uuid.UUID `param:"templateId"`
+}
+
+func (a *API) deleteTenderTemplate(c echo.Context) error {
+	var req tenderTemplateRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	err = a.service.DeleteTenderTemplate(c.Request().Context(), req.Username, req.TemplateID)
+	if err != nil {
+		return a.templateError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+type createTenderFromTemplateRequest struct {
+	TemplateID uuid.UUID         `param:"templateId"`
+	Values     map[string]string `json:"values"`
+}
+
+func (a *API) createTenderFromTemplate(c echo.Context) error {
+	var req createTenderFromTemplateRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	t, err := a.service.CreateTenderFromTemplate(c.Request().Context(), c.QueryParam("username"), req.TemplateID,
+		req.Values)
+	if err != nil {
+		return a.templateError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.tenderFromModel(t))
+}
+
+func (a *API) templateError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrTemplateNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidTemplate) || errors.Is(err, model.ErrMissingPlaceholder) ||
+		errors.Is(err, model.ErrInvalidVisibility) || errors.Is(err, model.ErrInvalidBidMode) ||
+		errors.Is(err, model.ErrCategoryNotFound) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type tenderTemplateResponse struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organizationId"`
+	Name           string    `json:"name"`
+	TenderName     string    `json:"tenderName"`
+	Description    string    `json:"description"`
+	CategoryID     uuid.UUID `json:"categoryId"`
+	ServiceType    string    `json:"serviceType"`
+	Visibility     string    `json:"visibility"`
+	BidMode        string    `json:"bidMode"`
+	Placeholders   []string  `json:"placeholders"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func (a *API) templateFromModel(template model.TenderTemplate) tenderTemplateResponse {
+	placeholders := template.Placeholders()
+	if placeholders == nil {
+		placeholders = []string{}
+	}
+
+	return tenderTemplateResponse{
+		ID:             template.ID,
+		OrganizationID: template.OrganizationID,
+		Name:           template.Name,
+		TenderName:     template.TenderName,
+		Description:    template.Description,
+		CategoryID:     template.CategoryID,
+		ServiceType:    template.Category,
+		Visibility:     string(template.Visibility),
+		BidMode:        string(template.BidMode),
+		Placeholders:   placeholders,
+		CreatedAt:      template.Created,
+	}
+}
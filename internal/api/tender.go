@@ -2,6 +2,7 @@ package api
 
 import (
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -12,10 +13,11 @@ import (
 )
 
 type tendersRequest struct {
-	Username    string `query:"username"`
-	ServiceType string `query:"serviceType"`
-	Limit       uint64 `query:"limit"`
-	Offset      uint64 `query:"offset"`
+	Username    string    `query:"username"`
+	CategoryID  uuid.UUID `query:"categoryId"`
+	ServiceType string    `query:"serviceType"`
+	Limit       uint64    `query:"limit"`
+	Offset      uint64    `query:"offset"`
 }
 
 func (a *API) tenders(c echo.Context) error {
@@ -27,13 +29,17 @@ func (a *API) tenders(c echo.Context) error {
 	}
 
 	opts := model.TenderFilter{
-		ServiceType: model.ServiceType(req.ServiceType),
-		Offset:      req.Offset,
-		Limit:       req.Limit,
+		CategoryID: req.CategoryID,
+		Category:   req.ServiceType,
+		Offset:     req.Offset,
+		Limit:      req.Limit,
 	}
 
 	tenders, err := a.service.Tenders(c.Request().Context(), req.Username, opts)
 	if err != nil {
+		if errors.Is(err, model.ErrCategoryNotFound) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -49,10 +55,11 @@ func (a *API) myTenders(c echo.Context) error {
 	}
 
 	opts := model.TenderFilter{
-		My:          true,
-		ServiceType: model.ServiceType(req.ServiceType),
-		Offset:      req.Offset,
-		Limit:       req.Limit,
+		My:         true,
+		CategoryID: req.CategoryID,
+		Category:   req.ServiceType,
+		Offset:     req.Offset,
+		Limit:      req.Limit,
 	}
 
 	tenders, err := a.service.Tenders(c.Request().Context(), req.Username, opts)
@@ -60,6 +67,9 @@ func (a *API) myTenders(c echo.Context) error {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrCategoryNotFound) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -91,11 +101,16 @@ func (a *API) tenderStatus(c echo.Context) error {
 }
 
 type createTenderRequest struct {
-	Name           string    `json:"name"`
-	Description    string    `json:"description"`
-	ServiceType    string    `json:"serviceType"`
-	OrganizationID uuid.UUID `json:"organizationId"`
-	Username       string    `json:"creatorUsername"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description"`
+	CategoryID     uuid.UUID  `json:"categoryId"`
+	ServiceType    string     `json:"serviceType"`
+	Visibility     string     `json:"visibility"`
+	BidMode        string     `json:"bidMode"`
+	BidsDeadline   *time.Time `json:"bidsDeadline"`
+	PublishAt      *time.Time `json:"publishAt"`
+	OrganizationID uuid.UUID  `json:"organizationId"`
+	Username       string     `json:"creatorUsername"`
 }
 
 func (a *API) createTender(c echo.Context) error {
@@ -109,15 +124,33 @@ func (a *API) createTender(c echo.Context) error {
 	tender := model.Tender{
 		Name:           req.Name,
 		Description:    req.Description,
-		ServiceType:    model.ServiceType(req.ServiceType),
+		CategoryID:     req.CategoryID,
+		Category:       req.ServiceType,
+		Visibility:     model.TenderVisibility(req.Visibility),
+		BidMode:        model.TenderBidMode(req.BidMode),
 		OrganizationID: req.OrganizationID,
 	}
 
+	if req.BidsDeadline != nil {
+		tender.BidsDeadline = *req.BidsDeadline
+	}
+
+	if req.PublishAt != nil {
+		tender.PublishAt = *req.PublishAt
+	}
+
 	t, err := a.service.CreateTender(c.Request().Context(), req.Username, tender)
 	if err != nil {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrInvalidVisibility) || errors.Is(err, model.ErrInvalidBidMode) ||
+			errors.Is(err, model.ErrInvalidPublishAt) || errors.Is(err, model.ErrCategoryNotFound) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -128,7 +161,9 @@ type updateTenderRequest struct {
 	TenderID    uuid.UUID `param:"tenderId"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
+	CategoryID  uuid.UUID `json:"categoryId"`
 	ServiceType string    `json:"serviceType"`
+	Visibility  string    `json:"visibility"`
 }
 
 func (a *API) updateTender(c echo.Context) error {
@@ -141,9 +176,11 @@ func (a *API) updateTender(c echo.Context) error {
 
 	tender := model.Tender{
 		ID:          req.TenderID,
-		Name:        c.QueryParam("username"),
+		Name:        req.Name,
 		Description: req.Description,
-		ServiceType: model.ServiceType(req.ServiceType),
+		CategoryID:  req.CategoryID,
+		Category:    req.ServiceType,
+		Visibility:  model.TenderVisibility(req.Visibility),
 	}
 
 	t, err := a.service.UpdateTender(c.Request().Context(), c.QueryParam("username"), tender)
@@ -151,6 +188,12 @@ func (a *API) updateTender(c echo.Context) error {
 		if errors.Is(err, model.ErrUserNotFound) {
 			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrTenderOrVersionNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusBadRequest, err.Error())
 	}
 
@@ -185,6 +228,9 @@ func (a *API) updateTenderStatus(c echo.Context) error {
 		if errors.Is(err, model.ErrCreatorNotFound) {
 			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -215,6 +261,171 @@ func (a *API) rollbackTender(c echo.Context) error {
 		if errors.Is(err, model.ErrCreatorNotFound) {
 			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
 		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, a.tenderFromModel(t))
+}
+
+type transferTenderRequest struct {
+	TenderID uuid.UUID `param:"tenderId"`
+}
+
+func (a *API) transferTender(c echo.Context) error {
+	var req transferTenderRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	t, err := a.service.TransferTender(c.Request().Context(), c.QueryParam("username"), req.TenderID,
+		c.QueryParam("owner"))
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrVersionNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrInvalidOwner) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, a.tenderFromModel(t))
+}
+
+type openTenderBidsRequest struct {
+	TenderID uuid.UUID `param:"tenderId"`
+}
+
+func (a *API) openTenderBids(c echo.Context) error {
+	var req openTenderBidsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	t, err := a.service.OpenTenderBids(c.Request().Context(), c.QueryParam("username"), req.TenderID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrVersionNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrBidsNotSealed) {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, a.tenderFromModel(t))
+}
+
+type scheduleTenderPublicationRequest struct {
+	TenderID  uuid.UUID  `param:"tenderId"`
+	PublishAt *time.Time `json:"publishAt"`
+}
+
+func (a *API) scheduleTenderPublication(c echo.Context) error {
+	var req scheduleTenderPublicationRequest
+
+	err := c.Bind(&req)
+	if err != nil || req.PublishAt == nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	t, err := a.service.ScheduleTenderPublication(c.Request().Context(), c.QueryParam("username"), req.TenderID,
+		*req.PublishAt)
+	if err != nil {
+		return a.publicationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.tenderFromModel(t))
+}
+
+type cancelTenderPublicationRequest struct {
+	Username string    `query:"username"`
+	TenderID uuid.UUID `param:"tenderId"`
+}
+
+func (a *API) cancelTenderPublication(c echo.Context) error {
+	var req cancelTenderPublicationRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	t, err := a.service.CancelTenderPublication(c.Request().Context(), req.Username, req.TenderID)
+	if err != nil {
+		return a.publicationError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.tenderFromModel(t))
+}
+
+func (a *API) publicationError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrTenderOrVersionNotFound) || errors.Is(err, model.ErrPublicationNotScheduled) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidPublishAt) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type cloneTenderRequest struct {
+	TenderID uuid.UUID `param:"tenderId"`
+}
+
+// cloneTender copies the current version of the tender, or the one given by the version query parameter.
+func (a *API) cloneTender(c echo.Context) error {
+	var req cloneTenderRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	var versionID int64
+	if v := c.QueryParam("version"); v != "" {
+		versionID, err = strconv.ParseInt(v, 10, 64)
+		if err != nil || versionID <= 0 {
+			return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+		}
+	}
+
+	t, err := a.service.CloneTender(c.Request().Context(), c.QueryParam("username"), req.TenderID, versionID)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrTenderOrVersionNotFound) {
+			return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+		}
+		if errors.Is(err, model.ErrNoRights) {
+			return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
 	}
 
@@ -222,13 +433,19 @@ func (a *API) rollbackTender(c echo.Context) error {
 }
 
 type tenderResponse struct {
-	ID          uuid.UUID `json:"id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	Status      string    `json:"status"`
-	ServiceType string    `json:"serviceType"`
-	Version     int64     `json:"version"`
-	CreatedAt   time.Time `json:"createdAt"`
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Description  string     `json:"description"`
+	Status       string     `json:"status"`
+	CategoryID   uuid.UUID  `json:"categoryId"`
+	ServiceType  string     `json:"serviceType"`
+	Visibility   string     `json:"visibility"`
+	BidMode      string     `json:"bidMode"`
+	BidsDeadline *time.Time `json:"bidsDeadline,omitempty"`
+	BidsOpenedAt *time.Time `json:"bidsOpenedAt,omitempty"`
+	PublishAt    *time.Time `json:"publishAt,omitempty"`
+	Version      int64      `json:"version"`
+	CreatedAt    time.Time  `json:"createdAt"`
 }
 
 func (a *API) tendersFromModel(tenders []model.Tender) []tenderResponse {
@@ -241,13 +458,30 @@ func (a *API) tendersFromModel(tenders []model.Tender) []tenderResponse {
 }
 
 func (a *API) tenderFromModel(tender model.Tender) tenderResponse {
-	return tenderResponse{
+	r := tenderResponse{
 		ID:          tender.ID,
 		Name:        tender.Name,
 		Description: tender.Description,
 		Status:      string(tender.Status),
-		ServiceType: string(tender.ServiceType),
+		CategoryID:  tender.CategoryID,
+		ServiceType: tender.Category,
+		Visibility:  string(tender.Visibility),
+		BidMode:     string(tender.BidMode),
 		Version:     tender.VersionID,
 		CreatedAt:   tender.Created,
 	}
+
+	if !tender.BidsDeadline.IsZero() {
+		r.BidsDeadline = &tender.BidsDeadline
+	}
+
+	if !tender.BidsOpened.IsZero() {
+		r.BidsOpenedAt = &tender.BidsOpened
+	}
+
+	if !tender.PublishAt.IsZero() {
+		r.PublishAt = &tender.PublishAt
+	}
+
+	return r
 }
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type webhooksRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `query:"organizationId"`
+}
+
+func (a *API) webhooks(c echo.Context) error {
+	var req webhooksRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	webhooks, err := a.service.Webhooks(c.Request().Context(), req.Username, req.OrganizationID)
+	if err != nil {
+		return a.webhookError(c, err)
+	}
+
+	r := make([]webhookResponse, 0, len(webhooks))
+	for _, w := range webhooks {
+		r = append(r, a.webhookFromModel(w))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type createWebhookRequest struct {
+	OrganizationID uuid.UUID `json:"organizationId"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"eventTypes"`
+	Secret         string    `json:"secret"`
+}
+
+func (a *API) createWebhook(c echo.Context) error {
+	var req createWebhookRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	webhook := model.Webhook{
+		OrganizationID: req.OrganizationID,
+		URL:            req.URL,
+		EventTypes:     make([]model.EventType, 0, len(req.EventTypes)),
+		Secret:         req.Secret,
+	}
+
+	for _, t := range req.EventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, model.EventType(t))
+	}
+
+	w, err := a.service.CreateWebhook(c.Request().Context(), c.QueryParam("username"), webhook)
+	if err != nil {
+		return a.webhookError(c, err)
+	}
+
+	r := a.webhookFromModel(w)
+	r.Secret = w.Secret
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type webhookRequest struct {
+	Username  string    `query:"username"`
+	WebhookID uuid.UUID `param:"webhookId"`
+}
+
+func (a *API) deleteWebhook(c echo.Context) error {
+	var req webhookRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	err = a.service.DeleteWebhook(c.Request().Context(), req.Username, req.WebhookID)
+	if err != nil {
+		return a.webhookError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+type webhookDeliveriesRequest struct {
+	Username  string    `query:"username"`
+	WebhookID uuid.UUID `param:"webhookId"`
+	Status    string    `query:"status"`
+	Limit     uint64    `query:"limit"`
+	Offset    uint64    `query:"offset"`
+}
+
+func (a *API) webhookDeliveries(c echo.Context) error {
+	var req webhookDeliveriesRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	opts := model.WebhookDeliveryFilter{
+		WebhookID: req.WebhookID,
+		Offset:    req.Offset,
+		Limit:     req.Limit,
+	}
+
+	if req.Status != "" {
+		opts.Status = []model.WebhookDeliveryStatus{model.WebhookDeliveryStatus(req.Status)}
+	}
+
+	deliveries, err := a.service.WebhookDeliveries(c.Request().Context(), req.Username, opts)
+	if err != nil {
+		return a.webhookError(c, err)
+	}
+
+	r := make([]webhookDeliveryResponse, 0, len(deliveries))
+	for _, d := range deliveries {
+		r = append(r, a.webhookDeliveryFromModel(d))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type redeliverWebhookRequest struct {
+	WebhookID  uuid.UUID `param:"webhookId"`
+	DeliveryID int64     `param:"deliveryId"`
+}
+
+func (a *API) redeliverWebhook(c echo.Context) error {
+	var req redeliverWebhookRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	d, err := a.service.RedeliverWebhook(c.Request().Context(), c.QueryParam("username"), req.WebhookID, req.DeliveryID)
+	if err != nil {
+		return a.webhookError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.webhookDeliveryFromModel(d))
+}
+
+func (a *API) webhookError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrWebhookNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrInvalidWebhookURL) || errors.Is(err, model.ErrInvalidWebhookEventType) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type webhookResponse struct {
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organizationId"`
+	URL            string    `json:"url"`
+	EventTypes     []string  `json:"eventTypes"`
+	Secret         string    `json:"secret,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+func (a *API) webhookFromModel(webhook model.Webhook) webhookResponse {
+	eventTypes := make([]string, 0, len(webhook.EventTypes))
+	for _, t := range webhook.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	return webhookResponse{
+		ID:             webhook.ID,
+		OrganizationID: webhook.OrganizationID,
+		URL:            webhook.URL,
+		EventTypes:     eventTypes,
+		CreatedAt:      webhook.Created,
+	}
+}
+
+type webhookDeliveryResponse struct {
+	ID           int64           `json:"id"`
+	WebhookID    uuid.UUID       `json:"webhookId"`
+	EventID      int64           `json:"eventId"`
+	EventType    string          `json:"eventType"`
+	Payload      json.RawMessage `json:"payload"`
+	Status       string          `json:"status"`
+	Attempts     int             `json:"attempts"`
+	ResponseCode int             `json:"responseCode,omitempty"`
+	LastError    string          `json:"lastError,omitempty"`
+	NextAttempt  time.Time       `json:"nextAttemptAt"`
+	DeliveredAt  *time.Time      `json:"deliveredAt,omitempty"`
+	CreatedAt    time.Time       `json:"createdAt"`
+}
+
+func (a *API) webhookDeliveryFromModel(d model.WebhookDelivery) webhookDeliveryResponse {
+	r := webhookDeliveryResponse{
+		ID:           d.ID,
+		WebhookID:    d.WebhookID,
+		EventID:      d.EventID,
+		EventType:    string(d.EventType),
+		Payload:      d.Payload,
+		Status:       string(d.Status),
+		Attempts:     d.Attempts,
+		ResponseCode: d.ResponseCode,
+		LastError:    d.LastError,
+		NextAttempt:  d.NextAttempt,
+		CreatedAt:    d.Created,
+	}
+
+	if !d.Delivered.IsZero() {
+		r.DeliveredAt = &d.Delivered
+	}
+
+	return r
+}
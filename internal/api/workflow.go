@@ -0,0 +1,207 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+
+	"zadanie-6105/internal/model"
+)
+
+type workflowsRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `param:"organizationId"`
+}
+
+func (a *API) workflows(c echo.Context) error {
+	var req workflowsRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	workflows, err := a.service.Workflows(c.Request().Context(), req.Username, req.OrganizationID)
+	if err != nil {
+		return a.workflowError(c, err)
+	}
+
+	r := make([]workflowResponse, 0, len(workflows))
+	for _, w := range workflows {
+		r = append(r, a.workflowFromModel(w))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+type saveWorkflowRequest struct {
+	OrganizationID uuid.UUID      `param:"organizationId"`
+	CategoryID     uuid.UUID      `json:"categoryId"`
+	Name           string         `json:"name"`
+	Stages         []stageRequest `json:"stages"`
+}
+
+type stageRequest struct {
+	Name      string   `json:"name"`
+	Quorum    int      `json:"quorum"`
+	Approvers []string `json:"approvers"`
+}
+
+func (a *API) saveWorkflow(c echo.Context) error {
+	var req saveWorkflowRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	workflow := model.Workflow{
+		OrganizationID: req.OrganizationID,
+		CategoryID:     req.CategoryID,
+		Name:           req.Name,
+		Stages:         make([]model.Stage, 0, len(req.Stages)),
+	}
+
+	approvers := make([][]string, 0, len(req.Stages))
+	for _, stage := range req.Stages {
+		workflow.Stages = append(workflow.Stages, model.Stage{Name: stage.Name, Quorum: stage.Quorum})
+		approvers = append(approvers, stage.Approvers)
+	}
+
+	w, err := a.service.SaveWorkflow(c.Request().Context(), c.QueryParam("username"), workflow, approvers)
+	if err != nil {
+		return a.workflowError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, a.workflowFromModel(w))
+}
+
+type workflowRequest struct {
+	Username       string    `query:"username"`
+	OrganizationID uuid.UUID `param:"organizationId"`
+	WorkflowID     uuid.UUID `param:"workflowId"`
+}
+
+func (a *API) deleteWorkflow(c echo.Context) error {
+	var req workflowRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	err = a.service.DeleteWorkflow(c.Request().Context(), req.Username, req.OrganizationID, req.WorkflowID)
+	if err != nil {
+		return a.workflowError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+type bidStagesRequest struct {
+	Username string    `query:"username"`
+	BidID    uuid.UUID `param:"bidId"`
+}
+
+func (a *API) bidStages(c echo.Context) error {
+	var req bidStagesRequest
+
+	err := c.Bind(&req)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": "invalid request format or params"})
+	}
+
+	results, err := a.service.BidStages(c.Request().Context(), req.Username, req.BidID)
+	if err != nil {
+		return a.workflowError(c, err)
+	}
+
+	r := make([]stageResultResponse, 0, len(results))
+	for _, result := range results {
+		r = append(r, a.stageResultFromModel(result))
+	}
+
+	return c.JSON(http.StatusOK, r)
+}
+
+func (a *API) workflowError(c echo.Context, err error) error {
+	if errors.Is(err, model.ErrInvalidWorkflow) || errors.Is(err, model.ErrCategoryNotFound) {
+		return c.JSON(http.StatusBadRequest, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrUserNotFound) {
+		return c.JSON(http.StatusUnauthorized, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrNoRights) {
+		return c.JSON(http.StatusForbidden, echo.Map{"reason": err.Error()})
+	}
+	if errors.Is(err, model.ErrWorkflowNotFound) || errors.Is(err, model.ErrTenderOrBidNotFound) ||
+		errors.Is(err, model.ErrTenderOrVersionNotFound) {
+		return c.JSON(http.StatusNotFound, echo.Map{"reason": err.Error()})
+	}
+	return c.JSON(http.StatusInternalServerError, echo.Map{"reason": err.Error()})
+}
+
+type workflowResponse struct {
+	ID             uuid.UUID       `json:"id"`
+	OrganizationID uuid.UUID       `json:"organizationId"`
+	CategoryID     *uuid.UUID      `json:"categoryId,omitempty"`
+	Name           string          `json:"name"`
+	Stages         []stageResponse `json:"stages"`
+	UpdatedAt      time.Time       `json:"updatedAt"`
+}
+
+type stageResponse struct {
+	Name      string   `json:"name"`
+	Quorum    int      `json:"quorum"`
+	Approvers []string `json:"approvers"`
+}
+
+func (a *API) workflowFromModel(workflow model.Workflow) workflowResponse {
+	r := workflowResponse{
+		ID:             workflow.ID,
+		OrganizationID: workflow.OrganizationID,
+		Name:           workflow.Name,
+		Stages:         make([]stageResponse, 0, len(workflow.Stages)),
+		UpdatedAt:      workflow.Updated,
+	}
+
+	if workflow.CategoryID != uuid.Nil {
+		r.CategoryID = &workflow.CategoryID
+	}
+
+	for _, stage := range workflow.Stages {
+		r.Stages = append(r.Stages, stageResponse{
+			Name:      stage.Name,
+			Quorum:    stage.Quorum,
+			Approvers: stage.Approvers,
+		})
+	}
+
+	return r
+}
+
+type stageResultResponse struct {
+	LotID     *uuid.UUID `json:"lotId,omitempty"`
+	Stage     int        `json:"stage"`
+	Name      string     `json:"name"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+func (a *API) stageResultFromModel(result model.StageResult) stageResultResponse {
+	r := stageResultResponse{
+		Stage:     result.Stage,
+		Name:      result.Name,
+		Status:    string(result.Status),
+		CreatedAt: result.Created,
+	}
+
+	if result.LotID != uuid.Nil {
+		r.LotID = &result.LotID
+	}
+
+	return r
+}
@@ -0,0 +1,60 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+// MaxAttachmentSize caps a single uploaded file.
+const MaxAttachmentSize = 20 << 20
+
+var (
+	ErrAttachmentNotFound = errors.New("attachment not found")
+	ErrInvalidAttachment  = errors.New("attachment needs a file name")
+	ErrAttachmentTooLarge = errors.New("attachment exceeds the size limit")
+	ErrAttachmentType     = errors.New("attachment type is not allowed")
+)
+
+// attachmentTypes lists the accepted content types: documents, spreadsheets, images and drawings.
+var attachmentTypes = map[string]struct{}{
+	"application/pdf":          {},
+	"application/zip":          {},
+	"application/msword":       {},
+	"application/vnd.ms-excel": {},
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   {},
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         {},
+	"application/vnd.openxmlformats-officedocument.presentationml.presentation": {},
+	"text/plain":       {},
+	"text/csv":         {},
+	"image/png":        {},
+	"image/jpeg":       {},
+	"image/gif":        {},
+	"image/webp":       {},
+	"image/tiff":       {},
+	"image/vnd.dwg":    {},
+	"image/vnd.dxf":    {},
+	"application/acad": {},
+	"application/dxf":  {},
+}
+
+func AttachmentTypeAllowed(contentType string) bool {
+	_, ok := attachmentTypes[contentType]
+	return ok
+}
+
+// Attachment is an uploaded file of a tender or a bid. Files are never changed after upload; removing one only
+// drops it from the current attachment set, earlier versions keep referring to it.
+type Attachment struct {
+	ID          uuid.UUID
+	EntityType  EntityType
+	EntityID    uuid.UUID
+	Name        string
+	ContentType string
+	Size        int64
+	Checksum    string
+	StorageKey  string
+	UploaderID  uuid.UUID
+	Created     time.Time
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrAuctionNotFound   = errors.New("auction not found")
+	ErrInvalidAuction    = errors.New("auction needs an open tender, an end after its start and a positive step")
+	ErrAuctionClosed     = errors.New("auction is not accepting offers")
+	ErrInvalidPrice      = errors.New("price must be positive")
+	ErrOfferTooHigh      = errors.New("offer must beat the best price by at least the auction step")
+	ErrAuctionInProgress = errors.New("decisions are taken after the auction ends")
+	ErrNotAuctionWinner  = errors.New("only the best offer of the auction can be approved")
+)
+
+// Auction is the reverse-auction window of a tender. The best offer is the lowest price placed so far, a bid
+// without offers taking part with the price it was published with.
+type Auction struct {
+	TenderID  uuid.UUID
+	Starts    time.Time
+	Ends      time.Time
+	Step      float64
+	Extension time.Duration
+	BestPrice float64
+	BestBidID uuid.UUID
+	Offers    int
+	CreatorID uuid.UUID
+	Created   time.Time
+}
+
+func (a Auction) Running(now time.Time) bool {
+	return !now.Before(a.Starts) && now.Before(a.Ends)
+}
+
+func (a Auction) Ended(now time.Time) bool {
+	return !now.Before(a.Ends)
+}
+
+type AuctionOffer struct {
+	ID         int64
+	TenderID   uuid.UUID
+	BidID      uuid.UUID
+	Price      float64
+	EmployeeID uuid.UUID
+	Created    time.Time
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AuditAction string
+
+const (
+	AuditActionCreate   AuditAction = "Create"
+	AuditActionEdit     AuditAction = "Edit"
+	AuditActionStatus   AuditAction = "StatusChange"
+	AuditActionRollback AuditAction = "Rollback"
+	AuditActionDecision AuditAction = "Decision"
+	AuditActionTransfer AuditAction = "Transfer"
+	AuditActionOpenBids AuditAction = "OpenBids"
+	AuditActionAuction  AuditAction = "Auction"
+	AuditActionOffer    AuditAction = "Offer"
+	AuditActionCriteria AuditAction = "Criteria"
+	AuditActionScore    AuditAction = "Score"
+	AuditActionAnswer   AuditAction = "Answer"
+	AuditActionAttach   AuditAction = "Attach"
+	AuditActionDetach   AuditAction = "Detach"
+	AuditActionClone    AuditAction = "Clone"
+	AuditActionSchedule AuditAction = "Schedule"
+	AuditActionDelete   AuditAction = "Delete"
+	AuditActionDeliver  AuditAction = "Deliver"
+	AuditActionBlock    AuditAction = "Block"
+)
+
+type AuditFilter struct {
+	EntityType      EntityType
+	EntityID        uuid.UUID
+	ActorID         uuid.UUID
+	From            time.Time
+	To              time.Time
+	EmployeeID      uuid.UUID
+	OrganizationIDs []uuid.UUID
+	Offset          uint64
+	Limit           uint64
+}
+
+type AuditEvent struct {
+	ID             int64
+	ActorID        uuid.UUID
+	Action         AuditAction
+	EntityType     EntityType
+	EntityID       uuid.UUID
+	OrganizationID uuid.UUID
+	Before         json.RawMessage
+	After          json.RawMessage
+	RequestID      string
+	Created        time.Time
+}
+
+type requestIDKey struct{}
+
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
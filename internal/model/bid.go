@@ -9,16 +9,27 @@ import (
 
 type BidStatus string
 
-var ErrTenderOrBidNotFound = errors.New("tender or bid not found")
+var (
+	ErrTenderOrBidNotFound = errors.New("tender or bid not found")
+	ErrInvalidDecision     = errors.New("decision must be Approved or Rejected")
+	ErrInvalidBidStatus    = errors.New("bid status must be Created, Published or Canceled")
+)
 
 const (
 	BidStatusCreated   BidStatus = "Created"
 	BidStatusPublished BidStatus = "Published"
 	BidStatusClosed    BidStatus = "Closed"
+	BidStatusCanceled  BidStatus = "Canceled"
 	BidStatusApproved  BidStatus = "Approved"
 	BidStatusRejected  BidStatus = "Rejected"
 )
 
+// Settable tells whether the author may move a bid to the status. Approval and rejection are decisions
+// of the tender organization and only come from SubmitBidDecision.
+func (s BidStatus) Settable() bool {
+	return s == BidStatusCreated || s == BidStatusPublished || s == BidStatusCanceled
+}
+
 type CreatorType string
 
 const (
@@ -26,8 +37,13 @@ const (
 	CreatorTypeUser         CreatorType = "User"
 )
 
+type BidSort string
+
+const BidSortScore BidSort = "score"
+
 type BidFilter struct {
 	My              bool
+	SortBy          BidSort
 	BidID           uuid.UUID
 	CreatorID       uuid.UUID
 	Status          []BidStatus
@@ -46,6 +62,33 @@ type Bid struct {
 	CreatorType    CreatorType
 	CreatorID      uuid.UUID
 	OrganizationID uuid.UUID
+	LotIDs         []uuid.UUID
+	Price          float64
+	Score          float64
+	Rank           int
+	AuthorRating   float64
+	AuthorRated    bool
+	Blacklisted    bool
 	VersionID      int64
+	Sealed         bool
 	Created        time.Time
 }
+
+type BidDecision struct {
+	BidID         uuid.UUID
+	LotID         uuid.UUID
+	Employee      Employee
+	Status        BidStatus
+	ApproverRoles []Role
+	Workflow      Workflow
+	DelegateID    uuid.UUID
+}
+
+// ActorID is who cast the decision: the delegate when voting on behalf of the approver.
+func (d BidDecision) ActorID() uuid.UUID {
+	if d.DelegateID != uuid.Nil {
+		return d.DelegateID
+	}
+
+	return d.Employee.ID
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrBlacklisted            = errors.New("bidder is blacklisted by the tender organization")
+	ErrBlacklistEntryNotFound = errors.New("blacklist entry not found")
+	ErrInvalidBlacklistEntry  = errors.New("blacklist entry needs either an organization or a username, a reason and a future expiry")
+)
+
+// BlacklistEntry excludes an organization or a single user from bidding on the tenders of an organization
+// until it expires; entries without expiry last until removed.
+type BlacklistEntry struct {
+	ID                    uuid.UUID
+	OrganizationID        uuid.UUID
+	BlockedOrganizationID uuid.UUID
+	BlockedEmployeeID     uuid.UUID
+	BlockedUsername       string
+	Reason                string
+	Expires               time.Time
+	CreatorID             uuid.UUID
+	Created               time.Time
+}
+
+// Active tells whether the entry still excludes the bidder at the given moment.
+func (e BlacklistEntry) Active(now time.Time) bool {
+	return e.Expires.IsZero() || e.Expires.After(now)
+}
@@ -0,0 +1,22 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCategoryNotFound = errors.New("category not found")
+	ErrInvalidCategory  = errors.New("category needs a name unique among its siblings and a parent outside its subtree")
+	ErrCategoryInUse    = errors.New("category still has subcategories, tenders or templates")
+)
+
+// Category is a node of the tender taxonomy; root categories have no parent.
+type Category struct {
+	ID       uuid.UUID
+	ParentID uuid.UUID
+	Name     string
+	Created  time.Time
+}
@@ -0,0 +1,160 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TenderHashScheme is the scheme new tender versions are hashed with. Scheme 2 also covers the category ID, so
+// that moving a tender to another category of the same name changes the hash; versions of scheme 1 only cover
+// the name and keep their original hash.
+const TenderHashScheme = 2
+
+type TenderVersion struct {
+	ID          int64
+	TenderID    uuid.UUID
+	Name        string
+	Description string
+	Status      TenderStatus
+	CategoryID  uuid.UUID
+	Category    string
+	EmployeeID  uuid.UUID
+	Lots        []LotSnapshot
+	Attachments []uuid.UUID
+	Created     time.Time
+	HashScheme  int
+	Hash        string
+	PrevHash    string
+}
+
+func (v TenderVersion) Link() ChainLink {
+	return ChainLink{VersionID: v.ID, Hash: v.Hash, PrevHash: v.PrevHash, ComputedHash: v.ComputeHash()}
+}
+
+func (v TenderVersion) ComputeHash() string {
+	fields := []string{
+		strconv.FormatInt(v.ID, 10),
+		v.TenderID.String(),
+		v.Name,
+		v.Description,
+		string(v.Status),
+		// The category name takes the place of the former service type, which keeps older hashes valid.
+		v.Category,
+		v.Created.UTC().Format(time.RFC3339Nano),
+	}
+
+	// Versions written before the author was recorded keep their original hash.
+	if v.EmployeeID != uuid.Nil {
+		fields = append(fields, v.EmployeeID.String())
+	}
+
+	if v.HashScheme >= 2 {
+		fields = append(fields, "category", v.CategoryID.String())
+	}
+
+	if len(v.Lots) > 0 {
+		fields = append(fields, "lots")
+		for _, l := range v.Lots {
+			fields = append(fields, l.ID.String(), l.Name, l.Description, string(l.Status))
+		}
+	}
+
+	return chainHash(v.PrevHash, appendAttachments(fields, v.Attachments)...)
+}
+
+type BidVersion struct {
+	ID          int64
+	BidID       uuid.UUID
+	Name        string
+	Description string
+	Status      BidStatus
+	Price       float64
+	Attachments []uuid.UUID
+	Created     time.Time
+	Hash        string
+	PrevHash    string
+}
+
+func (v BidVersion) Link() ChainLink {
+	return ChainLink{VersionID: v.ID, Hash: v.Hash, PrevHash: v.PrevHash, ComputedHash: v.ComputeHash()}
+}
+
+func (v BidVersion) ComputeHash() string {
+	fields := []string{
+		strconv.FormatInt(v.ID, 10),
+		v.BidID.String(),
+		v.Name,
+		v.Description,
+		string(v.Status),
+		v.Created.UTC().Format(time.RFC3339Nano),
+	}
+
+	// Versions written before the price was recorded, and bids without a price, keep their original hash.
+	if v.Price != 0 {
+		fields = append(fields, "price", strconv.FormatFloat(v.Price, 'f', 2, 64))
+	}
+
+	return chainHash(v.PrevHash, appendAttachments(fields, v.Attachments)...)
+}
+
+// appendAttachments adds the attachment set to the hashed fields; versions without attachments hash as before.
+func appendAttachments(fields []string, attachments []uuid.UUID) []string {
+	if len(attachments) == 0 {
+		return fields
+	}
+
+	fields = append(fields, "attachments")
+	for _, id := range attachments {
+		fields = append(fields, id.String())
+	}
+
+	return fields
+}
+
+type ChainLink struct {
+	VersionID    int64
+	Hash         string
+	PrevHash     string
+	ComputedHash string
+}
+
+type ChainVerification struct {
+	Valid    bool
+	Versions int
+	BrokenAt int64
+	Reason   string
+}
+
+// VerifyChain reports the first link that does not point to its predecessor or whose content was changed.
+func VerifyChain(links []ChainLink) ChainVerification {
+	var prev string
+	for _, l := range links {
+		if l.PrevHash != prev {
+			return ChainVerification{Versions: len(links), BrokenAt: l.VersionID, Reason: "previous hash mismatch"}
+		}
+
+		if l.ComputedHash != l.Hash {
+			return ChainVerification{Versions: len(links), BrokenAt: l.VersionID, Reason: "content hash mismatch"}
+		}
+
+		prev = l.Hash
+	}
+
+	return ChainVerification{Valid: true, Versions: len(links)}
+}
+
+// chainHash length-prefixes every field so that different field splits can never produce the same input.
+func chainHash(prevHash string, fields ...string) string {
+	h := sha256.New()
+	for _, f := range append([]string{prevHash}, fields...) {
+		h.Write([]byte(strconv.Itoa(len(f))))
+		h.Write([]byte{':'})
+		h.Write([]byte(f))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,194 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var chainCreated = time.Date(2024, 9, 1, 12, 30, 0, 123456000, time.UTC)
+
+func chainTenderVersion() TenderVersion {
+	return TenderVersion{
+		ID:          1,
+		TenderID:    uuid.MustParse("0191f5c0-0000-7000-8000-0000000000a1"),
+		Name:        "Road repair",
+		Description: "Repair of the ring road",
+		Status:      TenderStatusCreated,
+		Category:    "Construction",
+		Created:     chainCreated,
+	}
+}
+
+func chainBidVersion() BidVersion {
+	return BidVersion{
+		ID:          1,
+		BidID:       uuid.MustParse("0191f5c0-0000-7000-8000-0000000000b1"),
+		Name:        "Asphalt offer",
+		Description: "Asphalt in two layers",
+		Status:      BidStatusCreated,
+		Created:     chainCreated,
+	}
+}
+
+// The hashes were taken from versions written before attachments, prices and category IDs were hashed; such
+// versions must keep verifying.
+func TestComputeHashKeepsEarlierHashes(t *testing.T) {
+	if got, want := chainTenderVersion().ComputeHash(),
+		"b7781caebcfbad1e2eb662b7521b9b56278cdf0dcf186c22ccb59d7befcc8486"; got != want {
+		t.Errorf("TenderVersion.ComputeHash() = %s, want %s", got, want)
+	}
+
+	if got, want := chainBidVersion().ComputeHash(),
+		"f286104f971c080cf8c8b214eb9a5e692a63587a5127e5b9ec1f5d72284edea8"; got != want {
+		t.Errorf("BidVersion.ComputeHash() = %s, want %s", got, want)
+	}
+
+	v := chainTenderVersion()
+	v.Attachments = []uuid.UUID{}
+	if v.ComputeHash() != chainTenderVersion().ComputeHash() {
+		t.Error("an empty attachment set changes the tender hash")
+	}
+
+	b := chainBidVersion()
+	b.Attachments = []uuid.UUID{}
+	if b.ComputeHash() != chainBidVersion().ComputeHash() {
+		t.Error("an empty attachment set changes the bid hash")
+	}
+}
+
+func TestComputeHashCoversFields(t *testing.T) {
+	base := chainTenderVersion()
+	base.HashScheme = TenderHashScheme
+	base.CategoryID = uuid.MustParse("0191f5c0-0000-7000-8000-000000000001")
+
+	tenderChanges := map[string]func(v *TenderVersion){
+		"name":        func(v *TenderVersion) { v.Name += "." },
+		"status":      func(v *TenderVersion) { v.Status = TenderStatusPublished },
+		"category ID": func(v *TenderVersion) { v.CategoryID = uuid.MustParse("0191f5c0-0000-7000-8000-000000000002") },
+		"employee":    func(v *TenderVersion) { v.EmployeeID = uuid.New() },
+		"attachments": func(v *TenderVersion) { v.Attachments = []uuid.UUID{uuid.New()} },
+		"lots":        func(v *TenderVersion) { v.Lots = []LotSnapshot{{ID: uuid.New(), Name: "Lot 1"}} },
+		"prev hash":   func(v *TenderVersion) { v.PrevHash = "00" },
+		"scheme":      func(v *TenderVersion) { v.HashScheme = 1 },
+	}
+
+	for name, change := range tenderChanges {
+		v := base
+		change(&v)
+
+		if v.ComputeHash() == base.ComputeHash() {
+			t.Errorf("changing the tender %s keeps the hash", name)
+		}
+	}
+
+	bid := chainBidVersion()
+
+	bidChanges := map[string]func(v *BidVersion){
+		"description": func(v *BidVersion) { v.Description = "" },
+		"status":      func(v *BidVersion) { v.Status = BidStatusPublished },
+		"price":       func(v *BidVersion) { v.Price = 99.5 },
+		"attachments": func(v *BidVersion) { v.Attachments = []uuid.UUID{uuid.New()} },
+		"created":     func(v *BidVersion) { v.Created = v.Created.Add(time.Microsecond) },
+	}
+
+	for name, change := range bidChanges {
+		v := bid
+		change(&v)
+
+		if v.ComputeHash() == bid.ComputeHash() {
+			t.Errorf("changing the bid %s keeps the hash", name)
+		}
+	}
+}
+
+// A version is hashed before it is written and verified after it is read back: postgres keeps microseconds
+// in a timestamp without time zone, which pgx reads as UTC without a monotonic reading.
+func TestComputeHashSurvivesRoundTrip(t *testing.T) {
+	written := chainBidVersion()
+	written.Created = time.Now().UTC().Truncate(time.Microsecond)
+	written.Hash = written.ComputeHash()
+
+	read := written
+	read.Created = time.Date(written.Created.Year(), written.Created.Month(), written.Created.Day(),
+		written.Created.Hour(), written.Created.Minute(), written.Created.Second(), written.Created.Nanosecond(),
+		time.UTC)
+
+	if read.ComputeHash() != written.Hash {
+		t.Error("the hash changed on the round trip")
+	}
+
+	read.Created = written.Created.In(time.FixedZone("MSK", 3*60*60))
+	if read.ComputeHash() != written.Hash {
+		t.Error("the hash depends on the time zone of the creation time")
+	}
+}
+
+func TestVerifyChain(t *testing.T) {
+	chain := func() []BidVersion {
+		versions := make([]BidVersion, 0, 3)
+
+		var prev string
+		for i := int64(1); i <= 3; i++ {
+			v := chainBidVersion()
+			v.ID = i
+			v.Created = chainCreated.Add(time.Duration(i) * time.Minute)
+			v.PrevHash = prev
+			v.Hash = v.ComputeHash()
+
+			versions = append(versions, v)
+			prev = v.Hash
+		}
+
+		return versions
+	}
+
+	links := func(versions []BidVersion) []ChainLink {
+		l := make([]ChainLink, 0, len(versions))
+		for _, v := range versions {
+			l = append(l, v.Link())
+		}
+
+		return l
+	}
+
+	tests := []struct {
+		name     string
+		tamper   func(versions []BidVersion)
+		brokenAt int64
+		reason   string
+	}{
+		{"intact", func([]BidVersion) {}, 0, ""},
+		{"changed field", func(versions []BidVersion) { versions[1].Name = "Cheaper offer" }, 2,
+			"content hash mismatch"},
+		{"changed price", func(versions []BidVersion) { versions[2].Price = 1 }, 3, "content hash mismatch"},
+		{"broken link", func(versions []BidVersion) { versions[2].PrevHash = versions[0].Hash }, 3,
+			"previous hash mismatch"},
+		{"removed version", func(versions []BidVersion) { versions[1] = versions[2] }, 3,
+			"previous hash mismatch"},
+		{"rehashed version", func(versions []BidVersion) {
+			versions[1].Name = "Cheaper offer"
+			versions[1].Hash = versions[1].ComputeHash()
+		}, 3, "previous hash mismatch"},
+		{"first version with a predecessor", func(versions []BidVersion) { versions[0].PrevHash = "00" }, 1,
+			"previous hash mismatch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			versions := chain()
+			tt.tamper(versions)
+
+			got := VerifyChain(links(versions))
+
+			if got.Valid != (tt.brokenAt == 0) || got.BrokenAt != tt.brokenAt || got.Reason != tt.reason {
+				t.Errorf("VerifyChain() = %+v, want broken at %d: %q", got, tt.brokenAt, tt.reason)
+			}
+
+			if got.Versions != len(versions) {
+				t.Errorf("VerifyChain() counted %d versions, want %d", got.Versions, len(versions))
+			}
+		})
+	}
+}
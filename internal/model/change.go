@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Change struct {
+	EntityType EntityType  `json:"entityType"`
+	EntityID   uuid.UUID   `json:"entityId"`
+	TenderID   uuid.UUID   `json:"tenderId"`
+	VersionID  int64       `json:"version"`
+	Status     string      `json:"status"`
+	BestPrice  float64     `json:"bestPrice,omitempty"`
+	EndsAt     *time.Time  `json:"endsAt,omitempty"`
+	Created    time.Time   `json:"createdAt"`
+	Scope      ChangeScope `json:"-"`
+}
+
+// ChangeScope is what decides who may see a change, looked up once when the change is received so that
+// subscribers filter it without querying the database each.
+type ChangeScope struct {
+	TenderOrganizationID   uuid.UUID
+	TenderPublished        bool
+	TenderPublic           bool
+	InvitedOrganizationIDs []uuid.UUID
+	InvitedEmployeeIDs     []uuid.UUID
+	BidOrganizationID      uuid.UUID
+	BidStatus              BidStatus
+}
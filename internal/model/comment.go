@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCommentNotFound = errors.New("comment not found")
+	ErrInvalidComment  = errors.New("comment must not be empty and refer to a version and thread of the same bid")
+)
+
+type CommentFilter struct {
+	BidID      uuid.UUID
+	EmployeeID uuid.UUID
+	Offset     uint64
+	Limit      uint64
+}
+
+// Comment is a message in the discussion of a bid between the bidder and the tender organization.
+// Read is relative to the employee listing the comments.
+type Comment struct {
+	ID           uuid.UUID
+	BidID        uuid.UUID
+	BidVersionID int64
+	ParentID     uuid.UUID
+	AuthorID     uuid.UUID
+	Body         string
+	Mentions     []uuid.UUID
+	Read         bool
+	Created      time.Time
+}
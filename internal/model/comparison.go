@@ -0,0 +1,25 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+// BidTally sums up the evaluation of a bid so far: approver votes and the average score per criterion.
+type BidTally struct {
+	BidID      uuid.UUID
+	Approvals  int
+	Rejections int
+	Scores     map[uuid.UUID]float64
+}
+
+// Comparison lines up the visible bids of a tender against each other.
+type Comparison struct {
+	Tender   Tender
+	Criteria []Criterion
+	Rows     []ComparisonRow
+}
+
+type ComparisonRow struct {
+	Bid Bid
+	BidTally
+}
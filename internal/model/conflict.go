@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrConflictOfInterest = errors.New("employee acts for both the tender organization and the bidder")
+	ErrExemptionNotFound  = errors.New("conflict of interest exemption not found")
+	ErrInvalidExemption   = errors.New("exemption needs an existing employee and a reason")
+)
+
+// ConflictExemption lets an employee of the organization act on its tenders although they also belong
+// to a bidding organization.
+type ConflictExemption struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	EmployeeID     uuid.UUID
+	Username       string
+	Reason         string
+	CreatorID      uuid.UUID
+	Created        time.Time
+}
+
+// Conflict is an attempt blocked by the conflict of interest guard, kept in the audit trail of the tender
+// organization.
+type Conflict struct {
+	Action               Action
+	EmployeeID           uuid.UUID
+	TenderID             uuid.UUID
+	TenderOrganizationID uuid.UUID
+	BidID                uuid.UUID
+	BidOrganizationID    uuid.UUID
+}
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+const MaxScore = 10
+
+// Criterion weights are stored with two decimals below 10000.
+const (
+	MinCriterionWeight = 0.01
+	MaxCriterionWeight = 9999.99
+)
+
+var (
+	ErrInvalidCriteria = errors.New("criteria need a name and a weight from 0.01 to 9999.99")
+	ErrCriteriaScored  = errors.New("criteria cannot change once bids are scored")
+	ErrInvalidScore    = errors.New("scores range from 0 to 10 and must rate criteria of the tender")
+)
+
+// Criterion is one weighted aspect bids of a tender are scored on.
+type Criterion struct {
+	ID       uuid.UUID
+	TenderID uuid.UUID
+	Name     string
+	Weight   float64
+	Created  time.Time
+}
+
+// Score is the mark one approver gave a bid on one criterion.
+type Score struct {
+	BidID       uuid.UUID
+	CriterionID uuid.UUID
+	EmployeeID  uuid.UUID
+	Score       float64
+	Updated     time.Time
+}
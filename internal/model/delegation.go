@@ -0,0 +1,31 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrDelegationNotFound = errors.New("delegation not found")
+	ErrInvalidDelegation  = errors.New("delegation needs an approver and a colleague of the same organization " +
+		"and a period that has not ended")
+	ErrNotDelegated = errors.New("user has no delegation from the approver at this time")
+)
+
+// Delegation lets a colleague decide on bids on behalf of an approver of the organization for a period.
+// A delegated vote counts as the vote of the approver, so it takes their place in the quorum and
+// in approval stages.
+type Delegation struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	DelegatorID    uuid.UUID
+	Delegator      string
+	DelegateID     uuid.UUID
+	Delegate       string
+	Starts         time.Time
+	Ends           time.Time
+	CreatorID      uuid.UUID
+	Created        time.Time
+}
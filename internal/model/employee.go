@@ -12,4 +12,6 @@ type Employee struct {
 	ID              uuid.UUID
 	Username        string
 	OrganizationIDs []uuid.UUID
+	Roles           map[uuid.UUID]Role
+	PlatformAdmin   bool
 }
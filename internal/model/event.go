@@ -0,0 +1,74 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EntityType string
+
+const (
+	EntityTender     EntityType = "Tender"
+	EntityBid        EntityType = "Bid"
+	EntityAuction    EntityType = "Auction"
+	EntityLot        EntityType = "Lot"
+	EntityQuestion   EntityType = "Question"
+	EntityCategory   EntityType = "Category"
+	EntityReview     EntityType = "Review"
+	EntityExemption  EntityType = "Exemption"
+	EntityBlacklist  EntityType = "Blacklist"
+	EntityWorkflow   EntityType = "Workflow"
+	EntityDelegation EntityType = "Delegation"
+)
+
+type EventType string
+
+const (
+	EventTenderPublished  EventType = "TenderPublished"
+	EventTenderClosed     EventType = "TenderClosed"
+	EventTenderBidsOpened EventType = "TenderBidsOpened"
+	EventBidSubmitted     EventType = "BidSubmitted"
+	EventBidApproved      EventType = "BidApproved"
+	EventBidRejected      EventType = "BidRejected"
+)
+
+func (t EventType) Valid() bool {
+	switch t {
+	case EventTenderPublished, EventTenderClosed, EventTenderBidsOpened, EventBidSubmitted, EventBidApproved,
+		EventBidRejected:
+		return true
+	}
+
+	return false
+}
+
+type Event struct {
+	ID              int64
+	Type            EventType
+	EntityType      EntityType
+	EntityID        uuid.UUID
+	OrganizationIDs []uuid.UUID
+	Payload         json.RawMessage
+	Attempts        int
+	Created         time.Time
+}
+
+type TenderEventPayload struct {
+	TenderID       uuid.UUID    `json:"tenderId"`
+	Name           string       `json:"name"`
+	Status         TenderStatus `json:"status"`
+	OrganizationID uuid.UUID    `json:"organizationId"`
+	VersionID      int64        `json:"version"`
+}
+
+type BidEventPayload struct {
+	BidID                uuid.UUID `json:"bidId"`
+	Name                 string    `json:"name"`
+	Status               BidStatus `json:"status"`
+	TenderID             uuid.UUID `json:"tenderId"`
+	OrganizationID       uuid.UUID `json:"organizationId"`
+	TenderOrganizationID uuid.UUID `json:"tenderOrganizationId"`
+	VersionID            int64     `json:"version"`
+}
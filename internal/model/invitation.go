@@ -0,0 +1,44 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrInvalidInvitation  = errors.New("invitation must name exactly one organization or user")
+	ErrNotInvited         = errors.New("tender is invite-only and the bidder has no accepted invitation")
+)
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "Pending"
+	InvitationStatusAccepted InvitationStatus = "Accepted"
+	InvitationStatusDeclined InvitationStatus = "Declined"
+)
+
+type InvitationFilter struct {
+	InvitationID    uuid.UUID
+	TenderID        uuid.UUID
+	EmployeeID      uuid.UUID
+	OrganizationIDs []uuid.UUID
+	Status          []InvitationStatus
+	Offset          uint64
+	Limit           uint64
+}
+
+// Invitation admits either a whole organization or a single user to an invite-only tender.
+type Invitation struct {
+	ID             uuid.UUID
+	TenderID       uuid.UUID
+	OrganizationID uuid.UUID
+	EmployeeID     uuid.UUID
+	Status         InvitationStatus
+	InviterID      uuid.UUID
+	Created        time.Time
+	Responded      time.Time
+}
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLotNotFound = errors.New("lot not found or not open")
+	ErrLotRequired = errors.New("tender is split into lots, name the lots concerned")
+	ErrInvalidLot  = errors.New("lot needs a name and an open tender")
+	ErrLotHasBids  = errors.New("lot already has bids, cancel it instead")
+)
+
+type LotStatus string
+
+const (
+	LotStatusOpen     LotStatus = "Open"
+	LotStatusAwarded  LotStatus = "Awarded"
+	LotStatusCanceled LotStatus = "Canceled"
+	LotStatusRemoved  LotStatus = "Removed"
+)
+
+type Lot struct {
+	ID           uuid.UUID
+	TenderID     uuid.UUID
+	Name         string
+	Description  string
+	Status       LotStatus
+	AwardedBidID uuid.UUID
+	Created      time.Time
+}
+
+// LotSnapshot is the part of a lot recorded in every tender version.
+type LotSnapshot struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Status      LotStatus `json:"status"`
+}
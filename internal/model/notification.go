@@ -0,0 +1,73 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotificationNotFound          = errors.New("notification not found")
+	ErrInvalidNotificationPreference = errors.New("notification preference must refer to a notified event type")
+)
+
+// NotificationEventTypes are the events employees are notified about and can set preferences for.
+var NotificationEventTypes = []EventType{
+	EventTenderPublished,
+	EventTenderClosed,
+	EventTenderBidsOpened,
+	EventBidSubmitted,
+	EventBidApproved,
+	EventBidRejected,
+}
+
+type NotificationEmailStatus string
+
+const (
+	NotificationEmailPending NotificationEmailStatus = "Pending"
+	NotificationEmailSent    NotificationEmailStatus = "Sent"
+	NotificationEmailFailed  NotificationEmailStatus = "Failed"
+)
+
+// NotificationRecipients describes who hears about an event: every member of the organizations,
+// the author of the bid and the authors of all bids on the tender.
+type NotificationRecipients struct {
+	OrganizationIDs []uuid.UUID
+	BidID           uuid.UUID
+	TenderID        uuid.UUID
+}
+
+// NotificationPreference tells on which channels an employee wants to hear about an event type.
+// Without a stored preference both channels are on.
+type NotificationPreference struct {
+	EventType EventType
+	InApp     bool
+	Email     bool
+}
+
+type NotificationFilter struct {
+	EmployeeID uuid.UUID
+	Unread     bool
+	Offset     uint64
+	Limit      uint64
+}
+
+type Notification struct {
+	ID          int64
+	EmployeeID  uuid.UUID
+	EventID     int64
+	EventType   EventType
+	EntityType  EntityType
+	EntityID    uuid.UUID
+	Subject     string
+	Body        string
+	Read        time.Time
+	EmailStatus NotificationEmailStatus
+	Attempts    int
+	LastError   string
+	NextAttempt time.Time
+	Created     time.Time
+	Username    string
+	Email       string
+}
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	RoleViewer   Role = "viewer"
+	RoleEditor   Role = "editor"
+	RoleApprover Role = "approver"
+	RoleAdmin    Role = "admin"
+)
+
+type Action string
+
+const (
+	ActionTenderView        Action = "tender.view"
+	ActionTenderCreate      Action = "tender.create"
+	ActionTenderEdit        Action = "tender.edit"
+	ActionTenderStatus      Action = "tender.status"
+	ActionTenderRollback    Action = "tender.rollback"
+	ActionTenderTransfer    Action = "tender.transfer"
+	ActionTenderInvite      Action = "tender.invite"
+	ActionTenderOpenBids    Action = "tender.open_bids"
+	ActionTenderAuction     Action = "tender.auction"
+	ActionTenderAnswer      Action = "tender.answer"
+	ActionInvitationView    Action = "invitation.view"
+	ActionInvitationRespond Action = "invitation.respond"
+	ActionBidView           Action = "bid.view"
+	ActionBidCreate         Action = "bid.create"
+	ActionBidEdit           Action = "bid.edit"
+	ActionBidStatus         Action = "bid.status"
+	ActionBidRollback       Action = "bid.rollback"
+	ActionBidDecide         Action = "bid.decide"
+	ActionBidReview         Action = "bid.review"
+	ActionAuditView         Action = "audit.view"
+	ActionWebhookManage     Action = "webhook.manage"
+	ActionConflictManage    Action = "conflict.manage"
+	ActionBlacklistManage   Action = "blacklist.manage"
+	ActionWorkflowManage    Action = "workflow.manage"
+	ActionDelegationManage  Action = "delegation.manage"
+)
+
+// Resource is what an action is performed on: the organization that owns it and, when relevant, its creator.
+type Resource struct {
+	OrganizationID uuid.UUID
+	CreatorID      uuid.UUID
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrQuestionNotFound = errors.New("question not found")
+	ErrInvalidQuestion  = errors.New("question must not be empty")
+	ErrQuestionsClosed  = errors.New("questions can only be asked on a published tender")
+	ErrInvalidAnswer    = errors.New("answer must not be empty and be Private or Public")
+)
+
+type AnswerVisibility string
+
+const (
+	AnswerVisibilityPrivate AnswerVisibility = "Private"
+	AnswerVisibilityPublic  AnswerVisibility = "Public"
+)
+
+func (v AnswerVisibility) Valid() bool {
+	return v == AnswerVisibilityPrivate || v == AnswerVisibilityPublic
+}
+
+// QuestionFilter shows everything to the tender organization (All) and otherwise only the questions of
+// AuthorID and the publicly answered ones.
+type QuestionFilter struct {
+	TenderID   uuid.UUID
+	QuestionID uuid.UUID
+	AuthorID   uuid.UUID
+	All        bool
+	Offset     uint64
+	Limit      uint64
+}
+
+// Question is a clarification request on a tender. TenderVersionID is set when answering it changed the tender.
+type Question struct {
+	ID              uuid.UUID
+	TenderID        uuid.UUID
+	AuthorID        uuid.UUID
+	Question        string
+	Answer          string
+	Visibility      AnswerVisibility
+	AnswererID      uuid.UUID
+	TenderVersionID int64
+	Created         time.Time
+	Answered        time.Time
+}
@@ -0,0 +1,98 @@
+package model
+
+import (
+	"math"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrInvalidFeedback      = errors.New("feedback needs a text of at most 1000 characters and, if rated, a rating from 1 to 5")
+	ErrInvalidDelivery      = errors.New("delivery can only be confirmed for an approved bid")
+)
+
+const MaxRating = 5
+
+// Weights of the reputation components. A component without data is left out and the others are scaled up,
+// so a supplier is not punished for what nobody has recorded yet.
+const (
+	approvalWeight   = 0.35
+	reviewWeight     = 0.25
+	deliveryWeight   = 0.25
+	withdrawalWeight = 0.15
+)
+
+// Review is feedback of the tender organization on a bid. Rating is optional, zero means not rated.
+type Review struct {
+	ID          uuid.UUID
+	BidID       uuid.UUID
+	AuthorID    uuid.UUID
+	Description string
+	Rating      int
+	Created     time.Time
+}
+
+// ReviewFilter selects the reviews on bids created by an employee.
+type ReviewFilter struct {
+	CreatorID uuid.UUID
+	Offset    uint64
+	Limit     uint64
+}
+
+type Delivery struct {
+	BidID      uuid.UUID
+	OnTime     bool
+	EmployeeID uuid.UUID
+	Created    time.Time
+}
+
+// Reputation sums up the history of a bid author, an organization or a user bidding on their own. Bids,
+// approvals and withdrawals only count on closed tenders, withdrawals being bids canceled after publication.
+// Reviews only counts the rated ones.
+type Reputation struct {
+	SubjectType CreatorType
+	SubjectID   uuid.UUID
+	Bids        int
+	Approved    int
+	Withdrawn   int
+	Reviews     int
+	RatingSum   int
+	Deliveries  int
+	OnTime      int
+	Rating      float64
+	Rated       bool
+	Computed    time.Time
+}
+
+// ComputeRating weighs the components into a rating from 0 to MaxRating. It reports false when there is
+// nothing to rate yet.
+func (r Reputation) ComputeRating() (float64, bool) {
+	var score, weight float64
+
+	add := func(w, ratio float64) {
+		score += w * ratio
+		weight += w
+	}
+
+	if r.Bids > 0 {
+		add(approvalWeight, float64(r.Approved)/float64(r.Bids))
+		add(withdrawalWeight, 1-float64(r.Withdrawn)/float64(r.Bids))
+	}
+
+	if r.Reviews > 0 {
+		add(reviewWeight, (float64(r.RatingSum)/float64(r.Reviews)-1)/(MaxRating-1))
+	}
+
+	if r.Deliveries > 0 {
+		add(deliveryWeight, float64(r.OnTime)/float64(r.Deliveries))
+	}
+
+	if weight == 0 {
+		return 0, false
+	}
+
+	return math.Round(score/weight*MaxRating*100) / 100, true
+}
@@ -0,0 +1,76 @@
+package model
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTemplateNotFound   = errors.New("tender template not found")
+	ErrInvalidTemplate    = errors.New("template needs a name and a tender name")
+	ErrMissingPlaceholder = errors.New("template placeholders have no value")
+)
+
+// placeholderPattern matches {{name}} in the tender name and description of a template.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// TenderTemplate is a saved tender of an organization to start new tenders from.
+type TenderTemplate struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	TenderName     string
+	Description    string
+	CategoryID     uuid.UUID
+	Category       string
+	Visibility     TenderVisibility
+	BidMode        TenderBidMode
+	CreatorID      uuid.UUID
+	Created        time.Time
+}
+
+// Placeholders lists the distinct placeholder names of the template in order of appearance.
+func (t TenderTemplate) Placeholders() []string {
+	var names []string
+	for _, m := range placeholderPattern.FindAllStringSubmatch(t.TenderName+"\n"+t.Description, -1) {
+		if !slices.Contains(names, m[1]) {
+			names = append(names, m[1])
+		}
+	}
+
+	return names
+}
+
+// Fill makes a tender of the template with every placeholder replaced by its value.
+func (t TenderTemplate) Fill(values map[string]string) (Tender, error) {
+	var missing []string
+	for _, name := range t.Placeholders() {
+		if _, ok := values[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return Tender{}, errors.Wrap(ErrMissingPlaceholder, strings.Join(missing, ", "))
+	}
+
+	replace := func(s string) string {
+		return placeholderPattern.ReplaceAllStringFunc(s, func(m string) string {
+			return values[placeholderPattern.FindStringSubmatch(m)[1]]
+		})
+	}
+
+	return Tender{
+		Name:           replace(t.TenderName),
+		Description:    replace(t.Description),
+		CategoryID:     t.CategoryID,
+		Visibility:     t.Visibility,
+		BidMode:        t.BidMode,
+		OrganizationID: t.OrganizationID,
+	}, nil
+}
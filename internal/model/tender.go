@@ -10,6 +10,13 @@ import (
 var (
 	ErrCreatorNotFound         = errors.New("creator of tender not found")
 	ErrTenderOrVersionNotFound = errors.New("tender or version not found")
+	ErrInvalidOwner            = errors.New("new owner must be a responsible of the tender organization")
+	ErrInvalidVisibility       = errors.New("visibility must be Public or InviteOnly")
+	ErrInvalidBidMode          = errors.New("bid mode must be Open or Sealed")
+	ErrBidsSealed              = errors.New("bids are sealed until the deadline or manual opening")
+	ErrBidsNotSealed           = errors.New("tender bids are not sealed")
+	ErrInvalidPublishAt        = errors.New("publication can only be scheduled in the future on a created tender")
+	ErrPublicationNotScheduled = errors.New("tender publication is not scheduled")
 )
 
 type TenderStatus string
@@ -20,20 +27,38 @@ const (
 	TenderStatusClosed    TenderStatus = "Closed"
 )
 
-type ServiceType string
+type TenderVisibility string
 
 const (
-	TenderServiceTypeConstruction ServiceType = "Construction"
-	TenderServiceTypeDelivery     ServiceType = "Delivery"
-	TenderServiceTypeManufacture  ServiceType = "Manufacture"
+	TenderVisibilityPublic     TenderVisibility = "Public"
+	TenderVisibilityInviteOnly TenderVisibility = "InviteOnly"
 )
 
+func (v TenderVisibility) Valid() bool {
+	return v == TenderVisibilityPublic || v == TenderVisibilityInviteOnly
+}
+
+type TenderBidMode string
+
+const (
+	TenderBidModeOpen    TenderBidMode = "Open"
+	TenderBidModeSealed  TenderBidMode = "Sealed"
+	TenderBidModeAuction TenderBidMode = "Auction"
+)
+
+// Valid lists the modes a tender can be created with; auctions are set up on an open tender afterwards.
+func (m TenderBidMode) Valid() bool {
+	return m == TenderBidModeOpen || m == TenderBidModeSealed
+}
+
 type TenderFilter struct {
 	My              bool
+	EmployeeID      uuid.UUID
 	TenderID        uuid.UUID
 	CreatorID       uuid.UUID
 	VersionID       int64
-	ServiceType     ServiceType
+	CategoryID      uuid.UUID
+	Category        string
 	Status          []TenderStatus
 	OrganizationIDs []uuid.UUID
 	Offset          uint64
@@ -44,10 +69,32 @@ type Tender struct {
 	ID             uuid.UUID
 	Name           string
 	Description    string
-	ServiceType    ServiceType
+	CategoryID     uuid.UUID
+	Category       string
 	Status         TenderStatus
+	Visibility     TenderVisibility
+	BidMode        TenderBidMode
+	BidsDeadline   time.Time
+	BidsOpened     time.Time
+	PublishAt      time.Time
 	OrganizationID uuid.UUID
 	CreatorID      uuid.UUID
 	VersionID      int64
 	Created        time.Time
 }
+
+// Sealed reports whether bid contents are still hidden from the tender organization at the given moment.
+func (t Tender) Sealed(now time.Time) bool {
+	if t.BidMode != TenderBidModeSealed || !t.BidsOpened.IsZero() {
+		return false
+	}
+
+	return t.BidsDeadline.IsZero() || now.Before(t.BidsDeadline)
+}
+
+// ScheduledPublication is a tender due for publication on behalf of the employee who scheduled it.
+type ScheduledPublication struct {
+	Tender     Tender
+	EmployeeID uuid.UUID
+	Username   string
+}
@@ -0,0 +1,87 @@
+package model
+
+import (
+	"encoding/json"
+	"net/netip"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWebhookNotFound         = errors.New("webhook or delivery not found")
+	ErrInvalidWebhookURL       = errors.New("webhook url must be an absolute http or https url of a public host")
+	ErrInvalidWebhookEventType = errors.New("webhook event types must be known event types")
+	ErrWebhookAddress          = errors.New("webhook host resolves to a non-public address")
+)
+
+// internalPrefixes are the special-purpose ranges that netip does not classify but that still never lead to a
+// public host.
+var internalPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// PublicAddress tells whether webhooks may be delivered to the address. Loopback, private, link-local and other
+// internal ranges are refused, so that a webhook cannot reach the services around the deployment.
+func PublicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+
+	for _, p := range internalPrefixes {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type Webhook struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	URL            string
+	EventTypes     []EventType
+	Secret         string
+	CreatorID      uuid.UUID
+	Created        time.Time
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "Pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "Delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "Failed"
+)
+
+type WebhookDeliveryFilter struct {
+	WebhookID uuid.UUID
+	Status    []WebhookDeliveryStatus
+	Offset    uint64
+	Limit     uint64
+}
+
+type WebhookDelivery struct {
+	ID           int64
+	WebhookID    uuid.UUID
+	EventID      int64
+	EventType    EventType
+	Payload      json.RawMessage
+	Status       WebhookDeliveryStatus
+	Attempts     int
+	ResponseCode int
+	LastError    string
+	NextAttempt  time.Time
+	Delivered    time.Time
+	Created      time.Time
+	URL          string
+	Secret       string
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+)
+
+var (
+	ErrWorkflowNotFound = errors.New("workflow not found")
+	ErrInvalidWorkflow  = errors.New("workflow needs a name and stages, each with a name, approvers allowed " +
+		"to decide on bids and a quorum from 1 to the number of approvers")
+	ErrNotStageApprover = errors.New("user is not an approver of the current approval stage")
+	ErrStageRejected    = errors.New("bid was rejected in an approval stage")
+	ErrStagesPassed     = errors.New("bid has already passed all approval stages")
+)
+
+// Workflow is the staged approval of bids on the tenders of an organization. A workflow of a category also
+// covers its subcategories, one without category covers the tenders no other workflow does. Tenders without
+// a workflow keep the single vote of all approvers. A bid stays under the stages it got its first vote under,
+// and a bid first voted on without workflow keeps the single vote.
+type Workflow struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	CategoryID     uuid.UUID
+	Name           string
+	Stages         []Stage
+	CreatorID      uuid.UUID
+	Updated        time.Time
+}
+
+// Stage is passed once Quorum of its approvers approve and failed once any of them rejects.
+type Stage struct {
+	Name        string
+	Quorum      int
+	ApproverIDs []uuid.UUID
+	Approvers   []string
+}
+
+// StageResult is a settled stage in the approval history of a bid, or of one of its lots.
+type StageResult struct {
+	BidID   uuid.UUID
+	LotID   uuid.UUID
+	Stage   int
+	Name    string
+	Status  BidStatus
+	Created time.Time
+}
@@ -0,0 +1,108 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+)
+
+type LogSender struct{}
+
+func (LogSender) Send(_ context.Context, to, subject, body string) error {
+	log.Info().Str("to", to).Str("subject", subject).Str("body", body).Msg("email")
+
+	return nil
+}
+
+// SMTPSender sends plain text emails through an SMTP relay. Authentication is optional, which is what
+// local catch-all servers such as MailHog expect.
+type SMTPSender struct {
+	addr string
+	from mail.Address
+	auth smtp.Auth
+}
+
+func NewSMTPSender(addr, from, username, password string) (*SMTPSender, error) {
+	address, err := mail.ParseAddress(from)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse sender address")
+	}
+
+	s := &SMTPSender{addr: addr, from: *address}
+
+	if username != "" {
+		host, _, _ := strings.Cut(addr, ":")
+		s.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return s, nil
+}
+
+// Send does not honour the context, net/smtp has no way to cancel a conversation.
+func (s *SMTPSender) Send(_ context.Context, to, subject, body string) error {
+	msg, err := s.message(to, subject, body)
+	if err != nil {
+		return err
+	}
+
+	err = smtp.SendMail(s.addr, s.auth, s.from.Address, []string{to}, msg)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (s *SMTPSender) message(to, subject, body string) ([]byte, error) {
+	var msg bytes.Buffer
+
+	headers := [][2]string{
+		{"From", s.from.String()},
+		{"To", (&mail.Address{Address: to}).String()},
+		{"Subject", mime.QEncoding.Encode("utf-8", subject)},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", uuid.New(), s.domain())},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", `text/plain; charset="utf-8"`},
+		{"Content-Transfer-Encoding", "quoted-printable"},
+	}
+
+	for _, h := range headers {
+		_, _ = fmt.Fprintf(&msg, "%s: %s\r\n", h[0], h[1])
+	}
+
+	msg.WriteString("\r\n")
+
+	w := quotedprintable.NewWriter(&msg)
+
+	_, err := w.Write([]byte(strings.ReplaceAll(body, "\n", "\r\n")))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return msg.Bytes(), nil
+}
+
+func (s *SMTPSender) domain() string {
+	_, domain, _ := strings.Cut(s.from.Address, "@")
+	if domain == "" {
+		return "localhost"
+	}
+
+	return domain
+}
@@ -0,0 +1,77 @@
+package notification
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"testing"
+)
+
+func TestSMTPSenderMessage(t *testing.T) {
+	sender, err := NewSMTPSender("localhost:1025", "Tender Service <noreply@tender.example>", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subject := `Заявка "Поставка бетона" одобрена`
+	body := "Hello, ivan!\n\nBid \"Поставка бетона\" was approved = done." + strings.Repeat(" long line", 10) + "\n"
+
+	raw, err := sender.message("ivan@tender.example", subject, body)
+	if err != nil {
+		t.Fatalf("message() error = %v", err)
+	}
+
+	msg, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("message is not a valid email: %v", err)
+	}
+
+	if got := msg.Header.Get("From"); got != `"Tender Service" <noreply@tender.example>` {
+		t.Errorf("From = %s", got)
+	}
+
+	if got := msg.Header.Get("To"); got != "<ivan@tender.example>" {
+		t.Errorf("To = %s", got)
+	}
+
+	rawSubject := msg.Header.Get("Subject")
+	if !strings.HasPrefix(rawSubject, "=?utf-8?q?") {
+		t.Errorf("Subject = %s, want a Q-encoded word", rawSubject)
+	}
+
+	decoded, err := new(mime.WordDecoder).DecodeHeader(rawSubject)
+	if err != nil || decoded != subject {
+		t.Errorf("decoded Subject = %q (%v), want %q", decoded, err, subject)
+	}
+
+	if got := msg.Header.Get("Message-ID"); !strings.HasSuffix(got, "@tender.example>") {
+		t.Errorf("Message-ID = %s", got)
+	}
+
+	if got := msg.Header.Get("Content-Transfer-Encoding"); got != "quoted-printable" {
+		t.Errorf("Content-Transfer-Encoding = %s", got)
+	}
+
+	encoded, err := io.ReadAll(msg.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, line := range strings.Split(string(encoded), "\r\n") {
+		if len(line) > 76 {
+			t.Errorf("encoded body line of %d characters: %q", len(line), line)
+		}
+	}
+
+	content, err := io.ReadAll(quotedprintable.NewReader(bytes.NewReader(encoded)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := strings.ReplaceAll(body, "\n", "\r\n"); string(content) != want {
+		t.Errorf("decoded body = %q, want %q", content, want)
+	}
+}
@@ -0,0 +1,208 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"zadanie-6105/internal/model"
+)
+
+const (
+	defaultInterval    = time.Second
+	defaultBatch       = 50
+	defaultLease       = time.Minute
+	defaultMaxAttempts = 8
+	defaultMinBackoff  = 10 * time.Second
+	defaultMaxBackoff  = 6 * time.Hour
+)
+
+type Repository interface {
+	CreateNotifications(ctx context.Context, event model.Event, recipients model.NotificationRecipients,
+		subject, body string) error
+	ClaimNotificationEmails(ctx context.Context, limit uint64, lease time.Duration) ([]model.Notification, error)
+	MarkNotificationSent(ctx context.Context, notificationID int64) error
+	MarkNotificationFailed(ctx context.Context, notification model.Notification) error
+}
+
+// Dispatcher is an outbox publisher that turns lifecycle events into notifications of the employees concerned.
+type Dispatcher struct {
+	repository Repository
+}
+
+func NewDispatcher(repository Repository) *Dispatcher {
+	return &Dispatcher{repository: repository}
+}
+
+func (d *Dispatcher) Publish(ctx context.Context, event model.Event) error {
+	t, ok := eventTemplates[event.Type]
+	if !ok {
+		return nil
+	}
+
+	var (
+		payload    any
+		recipients model.NotificationRecipients
+	)
+
+	switch event.EntityType {
+	case model.EntityTender:
+		var p model.TenderEventPayload
+
+		err := json.Unmarshal(event.Payload, &p)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		payload = p
+		recipients = tenderRecipients(event.Type, p)
+	case model.EntityBid:
+		var p model.BidEventPayload
+
+		err := json.Unmarshal(event.Payload, &p)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		payload = p
+		recipients = bidRecipients(event.Type, p)
+	default:
+		return nil
+	}
+
+	subject, body, err := t.render(payload)
+	if err != nil {
+		return err
+	}
+
+	return d.repository.CreateNotifications(ctx, event, recipients, subject, body)
+}
+
+// tenderRecipients: the tender organization learns about the publication, bidders about everything that
+// happens to the tender afterwards.
+func tenderRecipients(eventType model.EventType, payload model.TenderEventPayload) model.NotificationRecipients {
+	if eventType == model.EventTenderPublished {
+		return model.NotificationRecipients{OrganizationIDs: []uuid.UUID{payload.OrganizationID}}
+	}
+
+	return model.NotificationRecipients{
+		OrganizationIDs: []uuid.UUID{payload.OrganizationID},
+		TenderID:        payload.TenderID,
+	}
+}
+
+// bidRecipients: the tender organization learns that a bid arrived, the bidder learns the decision.
+func bidRecipients(eventType model.EventType, payload model.BidEventPayload) model.NotificationRecipients {
+	if eventType == model.EventBidSubmitted {
+		return model.NotificationRecipients{OrganizationIDs: []uuid.UUID{payload.TenderOrganizationID}}
+	}
+
+	return model.NotificationRecipients{
+		OrganizationIDs: []uuid.UUID{payload.OrganizationID},
+		BidID:           payload.BidID,
+	}
+}
+
+type Sender interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// Mailer delivers the queued notification emails, retrying failures with backoff until they are given up on.
+type Mailer struct {
+	repository  Repository
+	sender      Sender
+	interval    time.Duration
+	batch       uint64
+	lease       time.Duration
+	maxAttempts int
+}
+
+func NewMailer(repository Repository, sender Sender) *Mailer {
+	return &Mailer{
+		repository:  repository,
+		sender:      sender,
+		interval:    defaultInterval,
+		batch:       defaultBatch,
+		lease:       defaultLease,
+		maxAttempts: defaultMaxAttempts,
+	}
+}
+
+func (m *Mailer) Run(ctx context.Context) {
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+
+	for {
+		err := m.send(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("notification mailer")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Mailer) send(ctx context.Context) error {
+	notifications, err := m.repository.ClaimNotificationEmails(ctx, m.batch, m.lease)
+	if err != nil {
+		return err
+	}
+
+	for _, n := range notifications {
+		err = m.deliver(ctx, n)
+		if err == nil {
+			err = m.repository.MarkNotificationSent(ctx, n.ID)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		log.Warn().Err(err).Int64("notification", n.ID).Msg("send notification email")
+
+		n.LastError = err.Error()
+		n.NextAttempt = time.Now().Add(backoff(n.Attempts))
+		n.EmailStatus = model.NotificationEmailPending
+		if n.Attempts+1 >= m.maxAttempts {
+			n.EmailStatus = model.NotificationEmailFailed
+		}
+
+		err = m.repository.MarkNotificationFailed(ctx, n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Mailer) deliver(ctx context.Context, notification model.Notification) error {
+	if notification.Email == "" {
+		return errors.New("recipient has no email address")
+	}
+
+	body, err := renderEmail(notification)
+	if err != nil {
+		return err
+	}
+
+	return m.sender.Send(ctx, notification.Email, notification.Subject, body)
+}
+
+func backoff(attempts int) time.Duration {
+	d := defaultMinBackoff
+	for i := 0; i < attempts && d < defaultMaxBackoff; i++ {
+		d *= 2
+	}
+
+	return min(d, defaultMaxBackoff)
+}
@@ -0,0 +1,170 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+func TestEventTemplates(t *testing.T) {
+	tender := model.TenderEventPayload{TenderID: uuid.New(), Name: "Road repair"}
+	bid := model.BidEventPayload{BidID: uuid.New(), Name: "Asphalt offer", TenderID: tender.TenderID}
+
+	for eventType, tmpl := range eventTemplates {
+		var payload any = tender
+		if strings.HasPrefix(string(eventType), "Bid") {
+			payload = bid
+		}
+
+		subject, body, err := tmpl.render(payload)
+		if err != nil {
+			t.Errorf("%s: render() error = %v", eventType, err)
+			continue
+		}
+
+		if subject == "" || body == "" || strings.Contains(subject+body, "<no value>") {
+			t.Errorf("%s: rendered %q / %q", eventType, subject, body)
+		}
+	}
+}
+
+func TestEventTemplatesSealedBid(t *testing.T) {
+	repository := &fakeRepository{}
+	dispatcher := NewDispatcher(repository)
+
+	// The outbox clears the name of a bid that is still sealed.
+	payload, err := json.Marshal(model.BidEventPayload{BidID: uuid.New(), TenderID: uuid.New(),
+		TenderOrganizationID: uuid.New()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = dispatcher.Publish(context.Background(), model.Event{
+		Type:       model.EventBidSubmitted,
+		EntityType: model.EntityBid,
+		Payload:    payload,
+	})
+	if err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if !strings.HasPrefix(repository.body, "A sealed bid") {
+		t.Errorf("body = %q, want it to refer to a sealed bid", repository.body)
+	}
+
+	if strings.Contains(repository.subject+repository.body, `""`) {
+		t.Errorf("rendered an empty bid name: %q / %q", repository.subject, repository.body)
+	}
+
+	// The subject never names the bid, sealed or not.
+	subject, _, err := eventTemplates[model.EventBidSubmitted].render(model.BidEventPayload{Name: "Secret offer"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(subject, "Secret offer") {
+		t.Errorf("subject = %q names the bid", subject)
+	}
+}
+
+func TestMailerSend(t *testing.T) {
+	repository := &fakeRepository{
+		notifications: []model.Notification{
+			{ID: 1, Email: "ok@tender.example", Username: "ok", Subject: "Subject", Body: "Body"},
+			{ID: 2, Email: "down@tender.example", Attempts: 1},
+			{ID: 3, Email: "down@tender.example", Attempts: defaultMaxAttempts - 1},
+			{ID: 4},
+		},
+	}
+
+	sender := &fakeSender{failing: "down@tender.example"}
+	mailer := NewMailer(repository, sender)
+
+	start := time.Now()
+
+	err := mailer.send(context.Background())
+	if err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+
+	if len(sender.sent) != 1 || !strings.Contains(sender.sent[0], "Hello, ok!") ||
+		!strings.Contains(sender.sent[0], "Body") {
+		t.Errorf("sent = %q", sender.sent)
+	}
+
+	if len(repository.sent) != 1 || repository.sent[0] != 1 {
+		t.Errorf("marked sent = %v, want [1]", repository.sent)
+	}
+
+	if len(repository.failed) != 3 {
+		t.Fatalf("marked failed = %d notifications, want 3", len(repository.failed))
+	}
+
+	retried := repository.failed[0]
+	if retried.EmailStatus != model.NotificationEmailPending || retried.LastError == "" {
+		t.Errorf("notification 2 = %s %q, want a pending retry", retried.EmailStatus, retried.LastError)
+	}
+
+	if wait := retried.NextAttempt.Sub(start); wait < backoff(1) || wait > backoff(1)+time.Minute {
+		t.Errorf("notification 2 next attempt in %s, want %s", wait, backoff(1))
+	}
+
+	if given := repository.failed[1]; given.EmailStatus != model.NotificationEmailFailed {
+		t.Errorf("notification 3 status = %s, want %s", given.EmailStatus, model.NotificationEmailFailed)
+	}
+
+	if missing := repository.failed[2]; missing.EmailStatus != model.NotificationEmailPending ||
+		!strings.Contains(missing.LastError, "no email address") {
+		t.Errorf("notification 4 = %s %q", missing.EmailStatus, missing.LastError)
+	}
+}
+
+type fakeRepository struct {
+	Repository
+
+	subject, body string
+	notifications []model.Notification
+	sent          []int64
+	failed        []model.Notification
+}
+
+func (r *fakeRepository) CreateNotifications(_ context.Context, _ model.Event, _ model.NotificationRecipients,
+	subject, body string) error {
+	r.subject, r.body = subject, body
+	return nil
+}
+
+func (r *fakeRepository) ClaimNotificationEmails(context.Context, uint64, time.Duration) ([]model.Notification, error) {
+	return r.notifications, nil
+}
+
+func (r *fakeRepository) MarkNotificationSent(_ context.Context, notificationID int64) error {
+	r.sent = append(r.sent, notificationID)
+	return nil
+}
+
+func (r *fakeRepository) MarkNotificationFailed(_ context.Context, notification model.Notification) error {
+	r.failed = append(r.failed, notification)
+	return nil
+}
+
+type fakeSender struct {
+	failing string
+	sent    []string
+}
+
+func (s *fakeSender) Send(_ context.Context, to, _, body string) error {
+	if to == s.failing {
+		return errors.New("connection refused")
+	}
+
+	s.sent = append(s.sent, body)
+	return nil
+}
@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/cockroachdb/errors"
+
+	"zadanie-6105/internal/model"
+)
+
+type eventTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+func newEventTemplate(name, subject, body string) eventTemplate {
+	return eventTemplate{
+		subject: template.Must(template.New(name + ".subject").Parse(subject)),
+		body:    template.Must(template.New(name + ".body").Parse(body)),
+	}
+}
+
+// eventTemplates render the in-app text of a notification from the event payload. Sealed bids reach the
+// tender organization without a name, the templates must not rely on it.
+var eventTemplates = map[model.EventType]eventTemplate{
+	model.EventTenderPublished: newEventTemplate("TenderPublished",
+		`Tender "{{.Name}}" was published`,
+		`Tender "{{.Name}}" ({{.TenderID}}) was published and accepts bids from now on.`),
+	model.EventTenderClosed: newEventTemplate("TenderClosed",
+		`Tender "{{.Name}}" was closed`,
+		`Tender "{{.Name}}" ({{.TenderID}}) was closed and no longer accepts bids.`),
+	model.EventTenderBidsOpened: newEventTemplate("TenderBidsOpened",
+		`Bids on tender "{{.Name}}" were opened`,
+		`The sealed bids on tender "{{.Name}}" ({{.TenderID}}) were opened and can be reviewed now.`),
+	model.EventBidSubmitted: newEventTemplate("BidSubmitted",
+		`A new bid arrived on tender {{.TenderID}}`,
+		`{{if .Name}}Bid "{{.Name}}"{{else}}A sealed bid{{end}} ({{.BidID}}) was submitted on tender {{.TenderID}}.`),
+	model.EventBidApproved: newEventTemplate("BidApproved",
+		`Bid "{{.Name}}" was approved`,
+		`Bid "{{.Name}}" ({{.BidID}}) on tender {{.TenderID}} reached the approval quorum and was accepted.`),
+	model.EventBidRejected: newEventTemplate("BidRejected",
+		`Bid "{{.Name}}" was rejected`,
+		`Bid "{{.Name}}" ({{.BidID}}) on tender {{.TenderID}} was rejected.`),
+}
+
+// emailTemplate wraps the notification text into the email sent to a recipient.
+var emailTemplate = template.Must(template.New("email").Parse(`Hello, {{.Username}}!
+
+{{.Body}}
+
+You receive this email because of your notification preferences, which you can change in the tender service.
+`))
+
+func (t eventTemplate) render(payload any) (string, string, error) {
+	var subject, body strings.Builder
+
+	err := t.subject.Execute(&subject, payload)
+	if err != nil {
+		return "", "", errors.WithStack(err)
+	}
+
+	err = t.body.Execute(&body, payload)
+	if err != nil {
+		return "", "", errors.WithStack(err)
+	}
+
+	return subject.String(), body.String(), nil
+}
+
+func renderEmail(notification model.Notification) (string, error) {
+	var body strings.Builder
+
+	err := emailTemplate.Execute(&body, notification)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return body.String(), nil
+}
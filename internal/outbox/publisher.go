@@ -0,0 +1,121 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/nats-io/nats.go"
+	"github.com/rs/zerolog/log"
+
+	"zadanie-6105/internal/model"
+)
+
+const defaultSubjectPrefix = "tender.events."
+
+// Publishers fans an event out to every publisher; a failure of any of them makes the relay retry the event
+// for all, which is fine under at-least-once delivery.
+type Publishers []Publisher
+
+func (p Publishers) Publish(ctx context.Context, event model.Event) error {
+	for _, publisher := range p {
+		err := publisher.Publish(ctx, event)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type LogPublisher struct{}
+
+func (LogPublisher) Publish(_ context.Context, event model.Event) error {
+	log.Info().
+		Int64("id", event.ID).
+		Str("type", string(event.Type)).
+		Str("entity", string(event.EntityType)).
+		Str("entityId", event.EntityID.String()).
+		RawJSON("payload", event.Payload).
+		Msg("event")
+
+	return nil
+}
+
+type NATSPublisher struct {
+	conn    *nats.Conn
+	prefix  string
+	timeout time.Duration
+}
+
+func NewNATSPublisher(url string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url, nats.Name("zadanie-6105"), nats.MaxReconnects(-1))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &NATSPublisher{
+		conn:    conn,
+		prefix:  defaultSubjectPrefix,
+		timeout: 5 * time.Second,
+	}, nil
+}
+
+// Publish flushes after every message, so an event counts as delivered only once the server has received it.
+func (p *NATSPublisher) Publish(ctx context.Context, event model.Event) error {
+	data, err := EncodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	msg := nats.NewMsg(p.prefix + string(event.Type))
+	msg.Data = data
+	msg.Header.Set(nats.MsgIdHdr, strconv.FormatInt(event.ID, 10))
+
+	err = p.conn.PublishMsg(msg)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	err = p.conn.FlushWithContext(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (p *NATSPublisher) Close() {
+	p.conn.Close()
+}
+
+type eventMessage struct {
+	ID         int64            `json:"id"`
+	Type       model.EventType  `json:"type"`
+	EntityType model.EntityType `json:"entityType"`
+	EntityID   uuid.UUID        `json:"entityId"`
+	Payload    json.RawMessage  `json:"payload"`
+	Created    time.Time        `json:"createdAt"`
+}
+
+func EncodeEvent(event model.Event) ([]byte, error) {
+	data, err := json.Marshal(eventMessage{
+		ID:         event.ID,
+		Type:       event.Type,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Payload:    event.Payload,
+		Created:    event.Created,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return data, nil
+}
@@ -0,0 +1,103 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"zadanie-6105/internal/model"
+)
+
+const (
+	defaultInterval   = time.Second
+	defaultBatch      = 100
+	defaultLease      = 30 * time.Second
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = time.Hour
+)
+
+type Repository interface {
+	ClaimEvents(ctx context.Context, limit uint64, lease time.Duration) ([]model.Event, error)
+	MarkEventPublished(ctx context.Context, eventID int64) error
+	MarkEventFailed(ctx context.Context, eventID int64, nextAttempt time.Time, reason string) error
+}
+
+type Publisher interface {
+	Publish(ctx context.Context, event model.Event) error
+}
+
+// Relay delivers outbox events at least once: an event is marked published only after the publisher
+// accepted it, so consumers must tolerate duplicates and deduplicate by event ID.
+type Relay struct {
+	repository Repository
+	publisher  Publisher
+	interval   time.Duration
+	batch      uint64
+	lease      time.Duration
+}
+
+func NewRelay(repository Repository, publisher Publisher) *Relay {
+	return &Relay{
+		repository: repository,
+		publisher:  publisher,
+		interval:   defaultInterval,
+		batch:      defaultBatch,
+		lease:      defaultLease,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		err := r.relay(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("outbox relay")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Relay) relay(ctx context.Context) error {
+	events, err := r.repository.ClaimEvents(ctx, r.batch, r.lease)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		err = r.publisher.Publish(ctx, event)
+		if err != nil {
+			log.Warn().Err(err).Int64("event", event.ID).Str("type", string(event.Type)).Msg("publish event")
+
+			err = r.repository.MarkEventFailed(ctx, event.ID, time.Now().Add(backoff(event.Attempts)), err.Error())
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		err = r.repository.MarkEventPublished(ctx, event.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func backoff(attempts int) time.Duration {
+	d := defaultMinBackoff
+	for i := 0; i < attempts && d < defaultMaxBackoff; i++ {
+		d *= 2
+	}
+
+	return min(d, defaultMaxBackoff)
+}
@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const attachmentColumns = `id, entity_type, entity_id, name, content_type, size, checksum, storage_key, uploader_id,
+	created`
+
+// Attachments lists the current attachment set of a tender or a bid.
+func (r *Repository) Attachments(ctx context.Context, entityType model.EntityType, entityID uuid.UUID) ([]model.Attachment, error) {
+	query := `
+	select ` + attachmentColumns + `
+	from attachment
+	where entity_type = $1
+	  and entity_id = $2
+	  and removed is null
+	order by created, id`
+
+	rows, err := r.pool.Query(ctx, query, entityType, entityID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	attachmentRows, err := pgx.CollectRows[attachmentRow](rows, pgx.RowToStructByNameLax[attachmentRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	attachments := make([]model.Attachment, 0, len(attachmentRows))
+	for _, row := range attachmentRows {
+		attachments = append(attachments, r.attachmentModel(row))
+	}
+
+	return attachments, nil
+}
+
+func (r *Repository) Attachment(ctx context.Context, entityType model.EntityType, entityID,
+	attachmentID uuid.UUID) (model.Attachment, error) {
+	query := `
+	select ` + attachmentColumns + `
+	from attachment
+	where id = $1
+	  and entity_type = $2
+	  and entity_id = $3
+	  and removed is null`
+
+	rows, err := r.pool.Query(ctx, query, attachmentID, entityType, entityID)
+	if err != nil {
+		return model.Attachment{}, errors.WithStack(err)
+	}
+
+	return r.collectAttachment(rows)
+}
+
+// CreateAttachment records an uploaded file and makes it part of a new version of its tender or bid.
+func (r *Repository) CreateAttachment(ctx context.Context, attachment model.Attachment) (model.Attachment, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Attachment{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	organizationID, err := r.attachmentOwnerForUpdate(ctx, tx, attachment.EntityType, attachment.EntityID)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+
+	query := `
+	insert into attachment (id, entity_type, entity_id, name, content_type, size, checksum, storage_key, uploader_id,
+	                        created)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	returning ` + attachmentColumns
+
+	rows, err := tx.Query(ctx, query, attachment.ID, attachment.EntityType, attachment.EntityID, attachment.Name,
+		attachment.ContentType, attachment.Size, attachment.Checksum, attachment.StorageKey, attachment.UploaderID,
+		time.Now())
+	if err != nil {
+		return model.Attachment{}, errors.WithStack(err)
+	}
+
+	a, err := r.collectAttachment(rows)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+
+	err = r.saveAttachmentChange(ctx, tx, a, organizationID, a.UploaderID, model.AuditActionAttach, nil, a)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Attachment{}, errors.WithStack(err)
+	}
+
+	return a, nil
+}
+
+// RemoveAttachment drops a file from the current attachment set. The file itself stays, since earlier versions
+// still list it and rolling back to them brings it back.
+func (r *Repository) RemoveAttachment(ctx context.Context, entityType model.EntityType, entityID, attachmentID,
+	employeeID uuid.UUID) (model.Attachment, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Attachment{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	organizationID, err := r.attachmentOwnerForUpdate(ctx, tx, entityType, entityID)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+
+	query := `
+	update attachment
+	set removed = $4
+	where id = $1
+	  and entity_type = $2
+	  and entity_id = $3
+	  and removed is null
+	returning ` + attachmentColumns
+
+	rows, err := tx.Query(ctx, query, attachmentID, entityType, entityID, time.Now())
+	if err != nil {
+		return model.Attachment{}, errors.WithStack(err)
+	}
+
+	a, err := r.collectAttachment(rows)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+
+	err = r.saveAttachmentChange(ctx, tx, a, organizationID, employeeID, model.AuditActionDetach, a, nil)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Attachment{}, errors.WithStack(err)
+	}
+
+	return a, nil
+}
+
+// attachmentOwnerForUpdate locks the tender or bid the attachment belongs to and returns its organization.
+func (r *Repository) attachmentOwnerForUpdate(ctx context.Context, tx pgx.Tx, entityType model.EntityType,
+	entityID uuid.UUID) (uuid.UUID, error) {
+	switch entityType {
+	case model.EntityTender:
+		tender, err := r.tenderForUpdate(ctx, tx, entityID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		return tender.OrganizationID, nil
+	case model.EntityBid:
+		bid, err := r.bidForUpdate(ctx, tx, entityID)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		return bid.OrganizationID, nil
+	default:
+		return uuid.Nil, errors.WithStack(model.ErrAttachmentNotFound)
+	}
+}
+
+// saveAttachmentChange writes the new version of the owner, which snapshots the attachment set, and the audit entry.
+func (r *Repository) saveAttachmentChange(ctx context.Context, tx pgx.Tx, attachment model.Attachment,
+	organizationID, employeeID uuid.UUID, action model.AuditAction, before, after any) error {
+	var err error
+
+	switch attachment.EntityType {
+	case model.EntityTender:
+		_, err = r.bumpTenderVersion(ctx, tx, attachment.EntityID, employeeID)
+	case model.EntityBid:
+		_, err = r.bumpBidVersion(ctx, tx, attachment.EntityID)
+	}
+	if err != nil {
+		return err
+	}
+
+	event := model.AuditEvent{
+		ActorID:        employeeID,
+		Action:         action,
+		EntityType:     attachment.EntityType,
+		EntityID:       attachment.EntityID,
+		OrganizationID: organizationID,
+	}
+
+	return r.saveAuditEvent(ctx, tx, event, before, after)
+}
+
+func (r *Repository) bumpBidVersion(ctx context.Context, tx pgx.Tx, bidID uuid.UUID) (model.Bid, error) {
+	query := `
+	update bid set version_id = version_id + 1 where id = $1
+	returning ` + bidColumns
+
+	rows, err := tx.Query(ctx, query, bidID)
+	if err != nil {
+		return model.Bid{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[bidRow](rows, pgx.RowToStructByNameLax[bidRow])
+	if err != nil {
+		return model.Bid{}, errors.WithStack(err)
+	}
+
+	b := r.bidModel(row)
+
+	err = r.saveBidVersion(ctx, tx, b)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	return b, nil
+}
+
+// attachmentSet is the snapshot of the current attachments stored with every version.
+func (r *Repository) attachmentSet(ctx context.Context, tx pgx.Tx, entityType model.EntityType,
+	entityID uuid.UUID) ([]uuid.UUID, error) {
+	query := `
+	select id
+	from attachment
+	where entity_type = $1
+	  and entity_id = $2
+	  and removed is null
+	order by id`
+
+	rows, err := tx.Query(ctx, query, entityType, entityID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return ids, nil
+}
+
+// restoreAttachments brings the attachment set back to the one of a version. Versions written before attachments
+// existed have no set, which means no attachments.
+func (r *Repository) restoreAttachments(ctx context.Context, tx pgx.Tx, entityType model.EntityType,
+	entityID uuid.UUID, versionID int64) error {
+	query := `select attachments from tender_version where tender_id = $1 and id = $2`
+	if entityType == model.EntityBid {
+		query = `select attachments from bid_version where bid_id = $1 and id = $2`
+	}
+
+	var ids []uuid.UUID
+
+	err := tx.QueryRow(ctx, query, entityID, versionID).Scan(&ids)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	query = `
+	update attachment
+	set removed = case when id = any ($3) then null else coalesce(removed, $4) end
+	where entity_type = $1
+	  and entity_id = $2`
+
+	_, err = tx.Exec(ctx, query, entityType, entityID, ids, time.Now())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) collectAttachment(rows pgx.Rows) (model.Attachment, error) {
+	row, err := pgx.CollectExactlyOneRow[attachmentRow](rows, pgx.RowToStructByNameLax[attachmentRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Attachment{}, errors.WithStack(model.ErrAttachmentNotFound)
+		}
+		return model.Attachment{}, errors.WithStack(err)
+	}
+
+	return r.attachmentModel(row), nil
+}
+
+func (r *Repository) attachmentModel(row attachmentRow) model.Attachment {
+	return model.Attachment{
+		ID:          row.ID,
+		EntityType:  model.EntityType(row.EntityType),
+		EntityID:    row.EntityID,
+		Name:        row.Name,
+		ContentType: row.ContentType,
+		Size:        row.Size,
+		Checksum:    row.Checksum,
+		StorageKey:  row.StorageKey,
+		UploaderID:  row.UploaderID,
+		Created:     row.Created,
+	}
+}
+
+type attachmentRow struct {
+	ID          uuid.UUID `db:"id"`
+	EntityType  string    `db:"entity_type"`
+	EntityID    uuid.UUID `db:"entity_id"`
+	Name        string    `db:"name"`
+	ContentType string    `db:"content_type"`
+	Size        int64     `db:"size"`
+	Checksum    string    `db:"checksum"`
+	StorageKey  string    `db:"storage_key"`
+	UploaderID  uuid.UUID `db:"uploader_id"`
+	Created     time.Time `db:"created"`
+}
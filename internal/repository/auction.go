@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+// auctionPrices lists the prices competing in the auction of tender $1: every offer, and for a bid that has not
+// placed one yet the price it was published with, which counts as its opening offer.
+const auctionPrices = `
+	select o.price, o.bid_id, o.created
+	from auction_offer o
+	where o.tender_id = $1
+	union all
+	select b.price, b.id, b.created
+	from bid b
+	where b.tender_id = $1
+	  and b.status in ('Published', 'Approved')
+	  and b.price is not null
+	  and not exists (select 1 from auction_offer o where o.bid_id = b.id)`
+
+const auctionQuery = `
+	select a.tender_id,
+	       a.starts,
+	       a.ends,
+	       a.step,
+	       a.extension_seconds,
+	       a.creator_id,
+	       a.created,
+	       p.price                                                               best_price,
+	       p.bid_id                                                              best_bid_id,
+	       (select count(*) from auction_offer c where c.tender_id = a.tender_id) offers
+	from auction a
+	         left join lateral (select price, bid_id
+	                            from (` + auctionPrices + `) p
+	                            order by price, created, bid_id
+	                            limit 1) p on true
+	where a.tender_id = $1`
+
+func (r *Repository) Auction(ctx context.Context, tenderID uuid.UUID) (model.Auction, error) {
+	rows, err := r.pool.Query(ctx, auctionQuery, tenderID)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	return r.collectAuction(rows)
+}
+
+// CreateAuction turns an open tender into a reverse auction.
+func (r *Repository) CreateAuction(ctx context.Context, auction model.Auction) (model.Auction, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := r.tenderForUpdate(ctx, tx, auction.TenderID)
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	if before.BidMode != model.TenderBidModeOpen || before.Status == model.TenderStatusClosed {
+		return model.Auction{}, errors.WithStack(model.ErrInvalidAuction)
+	}
+
+	query := `
+	insert into auction (tender_id, starts, ends, step, extension_seconds, creator_id, created)
+	values ($1, $2, $3, $4, $5, $6, $7)`
+
+	_, err = tx.Exec(ctx, query, auction.TenderID, auction.Starts, auction.Ends, auction.Step,
+		int64(auction.Extension/time.Second), auction.CreatorID, time.Now())
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	query = `
+	update tender set bid_mode = $2 where id = $1
+	returning ` + tenderColumns
+
+	rows, err := tx.Query(ctx, query, auction.TenderID, model.TenderBidModeAuction)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	rows, err = tx.Query(ctx, auctionQuery, auction.TenderID)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	a, err := r.collectAuction(rows)
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	err = r.saveTenderAudit(ctx, tx, auction.CreatorID, model.AuditActionAuction, before, r.tenderModel(row))
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	return a, nil
+}
+
+// PlaceOffer records a lower price for a bid. Offers on one auction are serialized by locking the auction row,
+// and an offer landing within the extension period of the end pushes the end out by that period.
+func (r *Repository) PlaceOffer(ctx context.Context, offer model.AuctionOffer) (model.Auction, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := r.bidForUpdate(ctx, tx, offer.BidID)
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	offer.TenderID = before.TenderID
+
+	query := `
+	select a.starts, a.ends, a.extension_seconds, t.status
+	from auction a
+	         join tender t on a.tender_id = t.id
+	where a.tender_id = $1
+	    for update of a`
+
+	var (
+		starts, ends time.Time
+		extension    int64
+		tenderStatus string
+	)
+
+	err = tx.QueryRow(ctx, query, offer.TenderID).Scan(&starts, &ends, &extension, &tenderStatus)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Auction{}, errors.WithStack(model.ErrAuctionNotFound)
+		}
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	now := time.Now()
+	window := model.Auction{Starts: starts, Ends: ends}
+
+	if !window.Running(now) || tenderStatus != string(model.TenderStatusPublished) ||
+		before.Status != model.BidStatusPublished {
+		return model.Auction{}, errors.WithStack(model.ErrAuctionClosed)
+	}
+
+	// The first offer already competes with the prices the bids were published with.
+	query = `
+	select coalesce($2 <= min(p.price) - a.step, true)
+	from auction a
+	         left join (` + auctionPrices + `) p on true
+	where a.tender_id = $1
+	group by a.step`
+
+	var beats bool
+	err = tx.QueryRow(ctx, query, offer.TenderID, offer.Price).Scan(&beats)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	if !beats {
+		return model.Auction{}, errors.WithStack(model.ErrOfferTooHigh)
+	}
+
+	query = `
+	insert into auction_offer (tender_id, bid_id, price, employee_id, created)
+	values ($1, $2, $3, $4, $5)`
+
+	_, err = tx.Exec(ctx, query, offer.TenderID, offer.BidID, offer.Price, offer.EmployeeID, now)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	query = `
+	update bid set price = $2, version_id = version_id + 1 where id = $1
+	returning ` + bidColumns
+
+	rows, err := tx.Query(ctx, query, offer.BidID, offer.Price)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[bidRow](rows, pgx.RowToStructByNameLax[bidRow])
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	b := r.bidModel(row)
+
+	// Every accepted offer is a version of the bid, so the price history is part of its hash chain.
+	err = r.saveBidVersion(ctx, tx, b)
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	extendTo := now.Add(time.Duration(extension) * time.Second)
+	if extendTo.After(ends) {
+		_, err = tx.Exec(ctx, `update auction set ends = $2 where tender_id = $1`, offer.TenderID, extendTo)
+		if err != nil {
+			return model.Auction{}, errors.WithStack(err)
+		}
+	}
+
+	err = r.saveBidAudit(ctx, tx, offer.EmployeeID, model.AuditActionOffer, before, b)
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	rows, err = tx.Query(ctx, auctionQuery, offer.TenderID)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	a, err := r.collectAuction(rows)
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	// Participants only learn the best price and the end of the window, never who placed the offer.
+	err = r.notifyChange(ctx, tx, model.Change{
+		EntityType: model.EntityAuction,
+		EntityID:   a.TenderID,
+		TenderID:   a.TenderID,
+		Status:     string(model.TenderStatusPublished),
+		BestPrice:  a.BestPrice,
+		EndsAt:     &a.Ends,
+		Created:    now,
+	})
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	return a, nil
+}
+
+func (r *Repository) collectAuction(rows pgx.Rows) (model.Auction, error) {
+	row, err := pgx.CollectExactlyOneRow[auctionRow](rows, pgx.RowToStructByNameLax[auctionRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Auction{}, errors.WithStack(model.ErrAuctionNotFound)
+		}
+		return model.Auction{}, errors.WithStack(err)
+	}
+
+	return r.auctionModel(row), nil
+}
+
+func (r *Repository) auctionModel(row auctionRow) model.Auction {
+	a := model.Auction{
+		TenderID:  row.TenderID,
+		Starts:    row.Starts,
+		Ends:      row.Ends,
+		Step:      row.Step,
+		Extension: time.Duration(row.ExtensionSeconds) * time.Second,
+		Offers:    row.Offers,
+		CreatorID: row.CreatorID,
+		Created:   row.Created,
+	}
+
+	if row.BestPrice != nil {
+		a.BestPrice = *row.BestPrice
+	}
+
+	if row.BestBidID != nil {
+		a.BestBidID = *row.BestBidID
+	}
+
+	return a
+}
+
+type auctionRow struct {
+	TenderID         uuid.UUID  `db:"tender_id"`
+	Starts           time.Time  `db:"starts"`
+	Ends             time.Time  `db:"ends"`
+	Step             float64    `db:"step"`
+	ExtensionSeconds int64      `db:"extension_seconds"`
+	CreatorID        uuid.UUID  `db:"creator_id"`
+	Created          time.Time  `db:"created"`
+	BestPrice        *float64   `db:"best_price"`
+	BestBidID        *uuid.UUID `db:"best_bid_id"`
+	Offers           int        `db:"offers"`
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+func (r *Repository) AuditEvents(ctx context.Context, opts model.AuditFilter) ([]model.AuditEvent, error) {
+	b := r.builder.
+		Select("id",
+			"actor_id",
+			"action",
+			"entity_type",
+			"entity_id",
+			"organization_id",
+			"before",
+			"after",
+			"request_id",
+			"created",
+		).From("audit_event").Where(sq.Or{
+		sq.Eq{"organization_id": opts.OrganizationIDs},
+		sq.Eq{"actor_id": opts.EmployeeID},
+	})
+
+	if opts.EntityType != "" {
+		b = b.Where(sq.Eq{"entity_type": opts.EntityType})
+	}
+
+	if opts.EntityID != uuid.Nil {
+		b = b.Where(sq.Eq{"entity_id": opts.EntityID})
+	}
+
+	if opts.ActorID != uuid.Nil {
+		b = b.Where(sq.Eq{"actor_id": opts.ActorID})
+	}
+
+	if !opts.From.IsZero() {
+		b = b.Where(sq.GtOrEq{"created": opts.From})
+	}
+
+	if !opts.To.IsZero() {
+		b = b.Where(sq.Lt{"created": opts.To})
+	}
+
+	if opts.Offset > 0 {
+		b = b.Offset(opts.Offset)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	b = b.OrderBy("id desc").Limit(limit)
+
+	query, args, err := b.ToSql()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	auditRows, err := pgx.CollectRows[auditEventRow](rows, pgx.RowToStructByNameLax[auditEventRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	events := make([]model.AuditEvent, 0, len(auditRows))
+	for _, row := range auditRows {
+		events = append(events, r.auditEventModel(row))
+	}
+
+	return events, nil
+}
+
+// saveAuditEvent must run in the transaction of the change it describes; nil snapshots are stored as null.
+func (r *Repository) saveAuditEvent(ctx context.Context, tx pgx.Tx, event model.AuditEvent, before, after any) error {
+	var err error
+
+	event.Before, err = r.auditSnapshot(before)
+	if err != nil {
+		return err
+	}
+
+	event.After, err = r.auditSnapshot(after)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	insert into audit_event (actor_id, action, entity_type, entity_id, organization_id, before, after, request_id, created)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = tx.Exec(ctx, query, event.ActorID, event.Action, event.EntityType, event.EntityID, nullUUID(event.OrganizationID),
+		event.Before, event.After, model.RequestID(ctx), time.Now(),
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) auditSnapshot(v any) (json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return data, nil
+}
+
+func (r *Repository) auditEventModel(row auditEventRow) model.AuditEvent {
+	var organizationID uuid.UUID
+	if row.OrganizationID != nil {
+		organizationID = *row.OrganizationID
+	}
+
+	return model.AuditEvent{
+		ID:             row.ID,
+		ActorID:        row.ActorID,
+		Action:         model.AuditAction(row.Action),
+		EntityType:     model.EntityType(row.EntityType),
+		EntityID:       row.EntityID,
+		OrganizationID: organizationID,
+		Before:         row.Before,
+		After:          row.After,
+		RequestID:      row.RequestID,
+		Created:        row.Created,
+	}
+}
+
+type auditEventRow struct {
+	ID             int64           `db:"id"`
+	ActorID        uuid.UUID       `db:"actor_id"`
+	Action         string          `db:"action"`
+	EntityType     string          `db:"entity_type"`
+	EntityID       uuid.UUID       `db:"entity_id"`
+	OrganizationID *uuid.UUID      `db:"organization_id"`
+	Before         json.RawMessage `db:"before"`
+	After          json.RawMessage `db:"after"`
+	RequestID      string          `db:"request_id"`
+	Created        time.Time       `db:"created"`
+}
@@ -2,7 +2,7 @@ package repository
 
 import (
 	"context"
-	"database/sql"
+	"slices"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -15,18 +15,46 @@ import (
 
 const minQuorum = 3
 
+const bidColumns = "id, name, description, status, tender_id, creator_type, creator_id, organization_id, price, " +
+	"version_id, created"
+
+// bidSealed holds for bids whose contents must stay hidden from the viewer: the tender collects sealed bids,
+// they have not been opened yet and the viewer is not from the bidding organization.
+const bidSealed = `t.bid_mode = 'Sealed'
+	and t.bids_opened is null
+	and (t.bids_deadline is null or t.bids_deadline > ?)
+	and b.organization_id <> all (?)`
+
+// bidReputationSubject is whose reputation a bid counts towards: the organization it was made on behalf of or
+// the employee bidding on their own.
+const bidReputationSubject = "case when b.creator_type = 'Organization' then b.organization_id else b.creator_id end"
+
 func (r *Repository) Bids(ctx context.Context, opts model.BidFilter) ([]model.Bid, error) {
+	now := time.Now()
+
 	b := r.builder.
-		Select("b.id",
-			"b.name",
-			"b.description",
-			"b.status",
+		Select("b.id").
+		Column(sq.Expr("case when "+bidSealed+" then '' else b.name end as name", now, opts.OrganizationIDs)).
+		Column(sq.Expr("case when "+bidSealed+" then '' else b.description end as description", now,
+			opts.OrganizationIDs)).
+		Column(sq.Expr("case when "+bidSealed+" then null else b.price end as price", now, opts.OrganizationIDs)).
+		Column(sq.Expr("("+bidSealed+") as sealed", now, opts.OrganizationIDs)).
+		Column(sq.Expr("case when t.organization_id = any (?) then "+bidScore+" end as score",
+			opts.OrganizationIDs)).
+		Column(sq.Expr("case when t.organization_id = any (?) and "+bidScore+" is not null "+
+			"then "+bidRank+" end as rank",
+			opts.OrganizationIDs)).
+		Column(sq.Expr("(t.organization_id = any (?) and "+bidBlacklisted+") as blacklisted",
+			opts.OrganizationIDs, now)).
+		Columns("b.status",
+			"array(select l.lot_id from bid_lot l where l.bid_id = b.id order by l.lot_id) as lot_ids",
 			"b.tender_id",
 			"b.creator_type",
 			"b.creator_id",
 			"b.organization_id",
 			"b.version_id",
 			"b.created",
+			"rep.rating as author_rating",
 		).From("bid b").Join("tender t on b.tender_id = t.id").Where(sq.Or{
 		sq.Eq{"b.organization_id": opts.OrganizationIDs},
 		sq.And{
@@ -35,6 +63,9 @@ func (r *Repository) Bids(ctx context.Context, opts model.BidFilter) ([]model.Bi
 		},
 	})
 
+	b = b.LeftJoin("supplier_reputation rep on rep.subject_type = b.creator_type and rep.subject_id = " +
+		bidReputationSubject)
+
 	if opts.BidID != uuid.Nil {
 		b = b.Where(sq.Eq{"b.id": opts.BidID})
 	}
@@ -51,6 +82,10 @@ func (r *Repository) Bids(ctx context.Context, opts model.BidFilter) ([]model.Bi
 		b = b.Where(sq.Eq{"b.status": opts.Status})
 	}
 
+	if opts.SortBy == model.BidSortScore {
+		b = b.OrderBy("score desc nulls last", "b.name", "b.id")
+	}
+
 	if opts.Offset > 0 {
 		b = b.Offset(opts.Offset)
 	}
@@ -95,13 +130,25 @@ func (r *Repository) CreateBid(ctx context.Context, bid model.Bid) (model.Bid, e
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
+	err = r.checkInvitation(ctx, tx, bid)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	err = r.checkBlacklist(ctx, tx, bid)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
 	query := `
-	insert into bid (id, name, description, status, tender_id, creator_type, creator_id, organization_id, version_id, created)
-	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	returning id, name, description, status, tender_id, creator_type, creator_id, organization_id, version_id, created`
+	insert into bid (id, name, description, status, tender_id, creator_type, creator_id, organization_id, price,
+	                 version_id, created)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	returning ` + bidColumns
 
 	rows, err := tx.Query(ctx, query,
-		bid.ID, bid.Name, bid.Description, bid.Status, bid.TenderID, bid.CreatorType, bid.CreatorID, bid.OrganizationID, 1, time.Now(),
+		bid.ID, bid.Name, bid.Description, bid.Status, bid.TenderID, bid.CreatorType, bid.CreatorID, bid.OrganizationID,
+		nullFloat(bid.Price), 1, time.Now(),
 	)
 	if err != nil {
 		return model.Bid{}, errors.WithStack(err)
@@ -113,12 +160,23 @@ func (r *Repository) CreateBid(ctx context.Context, bid model.Bid) (model.Bid, e
 	}
 
 	b := r.bidModel(row)
+	b.LotIDs = bid.LotIDs
+
+	err = r.saveBidLots(ctx, tx, b)
+	if err != nil {
+		return model.Bid{}, err
+	}
 
 	err = r.saveBidVersion(ctx, tx, b)
 	if err != nil {
 		return model.Bid{}, err
 	}
 
+	err = r.saveBidAudit(ctx, tx, bid.CreatorID, model.AuditActionCreate, nil, b)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return model.Bid{}, errors.WithStack(err)
@@ -134,17 +192,12 @@ func (r *Repository) UpdateBid(ctx context.Context, bid model.Bid) (model.Bid, e
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
+	before, err := r.bidForUpdate(ctx, tx, bid.ID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
 	b := r.builder.Update("bid").
-		PrefixExpr(
-			sq.StatementBuilder.
-				Select("id").
-				From("bid").
-				Where(sq.And{
-					sq.Eq{"id": bid.ID},
-					sq.Eq{"creator_id": bid.CreatorID},
-				}).
-				Prefix("with t as (").Suffix("for update)"),
-		).
 		Set("version_id", sq.Expr("version_id + 1"))
 
 	if bid.Name != "" {
@@ -159,10 +212,8 @@ func (r *Repository) UpdateBid(ctx context.Context, bid model.Bid) (model.Bid, e
 		b = b.Set("status", bid.Status)
 	}
 
-	b = b.Where(sq.And{
-		sq.Eq{"id": bid.ID},
-		sq.Eq{"creator_id": bid.CreatorID},
-	}).Suffix("returning id, name, description, status, tender_id, creator_type, creator_id, organization_id, version_id, created")
+	b = b.Where(sq.Eq{"id": bid.ID}).
+		Suffix("returning " + bidColumns)
 
 	query, args, err := b.ToSql()
 	if err != nil {
@@ -186,6 +237,21 @@ func (r *Repository) UpdateBid(ctx context.Context, bid model.Bid) (model.Bid, e
 		return model.Bid{}, err
 	}
 
+	action := model.AuditActionEdit
+	if bid.Status != "" {
+		action = model.AuditActionStatus
+	}
+
+	err = r.saveBidAudit(ctx, tx, bid.CreatorID, action, before, bb)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	err = r.saveBidEvents(ctx, tx, before, bb)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return model.Bid{}, errors.WithStack(err)
@@ -194,16 +260,20 @@ func (r *Repository) UpdateBid(ctx context.Context, bid model.Bid) (model.Bid, e
 	return bb, nil
 }
 
-func (r *Repository) RollbackBid(ctx context.Context, bidID uuid.UUID, versionID int64, creatorID uuid.UUID) (model.Bid, error) {
+func (r *Repository) RollbackBid(ctx context.Context, bidID uuid.UUID, versionID int64, employeeID uuid.UUID) (model.Bid, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return model.Bid{}, errors.WithStack(err)
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
+	before, err := r.bidForUpdate(ctx, tx, bidID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
 	query := `
-	with u as (select id from tender where id = $1 and creator_id = $3 for update),
-	     v as (select name, description, status
+	with v as (select name, description, status, price
 	           from bid_version
 	           where bid_id = $1
 	             and id = $2)
@@ -211,28 +281,48 @@ func (r *Repository) RollbackBid(ctx context.Context, bidID uuid.UUID, versionID
 	set name        = v.name,
 	    description = v.description,
 	    status      = v.status,
+	    price       = coalesce(v.price, b.price),
 	    version_id  = version_id + 1
 	from v
-	where id = $1 and creator_id = $3
-	returning b.id, b.name, b.description, b.status, b.organization_id, b.creator_id, b.version_id, b.created`
+	where id = $1
+	returning b.id, b.name, b.description, b.status, b.tender_id, b.creator_type, b.organization_id, b.creator_id,
+	          b.price, b.version_id, b.created`
 
-	rows, err := tx.Query(ctx, query, bidID, versionID, creatorID)
+	rows, err := tx.Query(ctx, query, bidID, versionID)
 	if err != nil {
 		return model.Bid{}, errors.WithStack(err)
 	}
 
 	row, err := pgx.CollectExactlyOneRow[bidRow](rows, pgx.RowToStructByNameLax[bidRow])
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Bid{}, errors.WithStack(model.ErrTenderOrBidNotFound)
+		}
 		return model.Bid{}, errors.WithStack(err)
 	}
 
 	b := r.bidModel(row)
 
+	err = r.restoreAttachments(ctx, tx, model.EntityBid, bidID, versionID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
 	err = r.saveBidVersion(ctx, tx, b)
 	if err != nil {
 		return model.Bid{}, err
 	}
 
+	err = r.saveBidAudit(ctx, tx, employeeID, model.AuditActionRollback, before, b)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	err = r.saveBidEvents(ctx, tx, before, b)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return model.Bid{}, errors.WithStack(err)
@@ -241,74 +331,108 @@ func (r *Repository) RollbackBid(ctx context.Context, bidID uuid.UUID, versionID
 	return b, nil
 }
 
-func (r *Repository) SubmitBidDecision(ctx context.Context, bidID uuid.UUID, employee model.Employee, status model.BidStatus) (model.Bid, error) {
+func (r *Repository) SubmitBidDecision(ctx context.Context, decision model.BidDecision) (model.Bid, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return model.Bid{}, errors.WithStack(err)
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
+	bidID, employee := decision.BidID, decision.Employee
+
+	before, err := r.bidForUpdate(ctx, tx, bidID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	decision.Workflow, err = r.pinWorkflow(ctx, tx, decision)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	// Under a workflow only the approvers of the stage the bid has reached vote, and their votes count
+	// towards that stage alone.
+	staged := len(decision.Workflow.Stages) > 0
+
+	var (
+		stage         int
+		stagePosition *int
+	)
+
+	if staged {
+		stage, err = r.currentStage(ctx, tx, decision)
+		if err != nil {
+			return model.Bid{}, err
+		}
+
+		if !slices.Contains(decision.Workflow.Stages[stage].ApproverIDs, employee.ID) {
+			return model.Bid{}, errors.WithStack(model.ErrNotStageApprover)
+		}
+
+		position := stage + 1
+		stagePosition = &position
+	}
+
+	// A bid already approved for one lot stays open to decisions on its other lots, but not on a lot it has
+	// been rejected for.
 	query := `
 	insert
-	into bid_agreement (bid_id, employee_id, status)
-	select $1, $2, $3
+	into bid_agreement (bid_id, lot_id, stage, employee_id, delegate_id, status, created)
+	select $1, $5::uuid, $6::smallint, $2, $7::uuid, $3, $4
 	from bid b
 	         join tender t on b.tender_id = t.id
 	where b.id = $1
-	  and b.status = 'Published'
+	  and (b.status = 'Published' or ($5::uuid is not null and b.status = 'Approved'))
 	  and t.status = 'Published'
- 	  and t.organization_id = any($4)
-	    for update
+	  and ($5::uuid is null or exists (select 1
+	                                   from bid_lot bl
+	                                            join tender_lot l on bl.lot_id = l.id
+	                                   where bl.bid_id = b.id
+	                                     and l.id = $5
+	                                     and l.status = 'Open'))
+	  and not exists (select 1
+	                  from bid_agreement a
+	                  where a.bid_id = b.id
+	                    and a.lot_id is not distinct from $5::uuid
+	                    and a.status = 'Rejected')
+	    for update of b
 	returning bid_id`
 
 	var id uuid.UUID
-	err = tx.QueryRow(ctx, query, bidID, employee.ID, status, employee.OrganizationIDs).Scan(&id)
+	err = tx.QueryRow(ctx, query, bidID, employee.ID, decision.Status, time.Now(), nullUUID(decision.LotID),
+		stagePosition, nullUUID(decision.DelegateID)).Scan(&id)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Bid{}, errors.WithStack(model.ErrTenderOrBidNotFound)
+		}
 		return model.Bid{}, errors.WithStack(err)
 	}
 
-	query = `
-	with q as (select least(count(o.employee_id), $2) count
-	           from bid b
-	                    join tender t on b.tender_id = t.id
-	                    join organization_employee o on t.organization_id = o.organization_id
-	           where b.id = $1)
-	select status from bid_agreement
-	where bid_id = $1 and status = 'Approved'
-	group by status having count(bid_id) >= (select count from q)
-	union
-	select status from bid_agreement
-	where bid_id = $1 and status = 'Rejected'
-	group by status having count(bid_id) >= 1`
-
-	var bidStatus model.BidStatus
-
-	err = tx.QueryRow(ctx, query, bidID, minQuorum).Scan(&bidStatus)
-	if err != nil && !errors.Is(err, sql.ErrNoRows) {
-		return model.Bid{}, errors.WithStack(err)
-	}
+	var outcome model.BidStatus
 
-	var b model.Bid
+	if staged {
+		outcome, err = r.stageOutcome(ctx, tx, decision, stage)
+	} else {
+		outcome, err = r.quorumOutcome(ctx, tx, decision)
+	}
+	if err != nil {
+		return model.Bid{}, err
+	}
 
-	if bidStatus == "" {
-		query = `
-		select id, name, description, status, tender_id, creator_type, creator_id, organization_id, version_id, created
-		from bid where id = $1`
+	bidStatus := outcome
 
-		rows, err := tx.Query(ctx, query, bidID, bidStatus)
+	if decision.LotID != uuid.Nil {
+		bidStatus, err = r.lotOutcome(ctx, tx, before, decision, outcome)
 		if err != nil {
-			return model.Bid{}, errors.WithStack(err)
+			return model.Bid{}, err
 		}
+	}
 
-		row, err := pgx.CollectExactlyOneRow[bidRow](rows, pgx.RowToStructByNameLax[bidRow])
-		if err != nil {
-			return model.Bid{}, errors.WithStack(err)
-		}
+	b := before
 
-		b = r.bidModel(row)
-	} else {
+	if bidStatus != "" && bidStatus != before.Status {
 		query = `update bid set status = $2, version_id = version_id + 1 where id = $1
-		returning id, name, description, status, tender_id, creator_type, creator_id, organization_id, version_id, created`
+		returning ` + bidColumns
 
 		rows, err := tx.Query(ctx, query, bidID, bidStatus)
 		if err != nil {
@@ -320,34 +444,54 @@ func (r *Repository) SubmitBidDecision(ctx context.Context, bidID uuid.UUID, emp
 			return model.Bid{}, errors.WithStack(err)
 		}
 
-		b := r.bidModel(row)
+		b = r.bidModel(row)
 
 		err = r.saveBidVersion(ctx, tx, b)
 		if err != nil {
 			return model.Bid{}, err
 		}
 
-		if bidStatus == model.BidStatusApproved {
-			query = `update tender set status = $2, version_id = version_id + 1 where id = $1
-			returning id, name, description, status, service_type, organization_id, creator_id, version_id, created`
+		err = r.saveBidEvents(ctx, tx, before, b)
+		if err != nil {
+			return model.Bid{}, err
+		}
 
-			rows, err := tx.Query(ctx, query, b.TenderID, model.TenderStatusClosed)
+		if bidStatus == model.BidStatusApproved && decision.LotID == uuid.Nil {
+			err = r.closeTender(ctx, tx, b.TenderID, decision.ActorID())
 			if err != nil {
-				return model.Bid{}, errors.WithStack(err)
+				return model.Bid{}, err
 			}
+		}
+	}
 
-			row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
-			if err != nil {
-				return model.Bid{}, errors.WithStack(err)
-			}
+	var tenderOrganizationID uuid.UUID
 
-			tender := r.tenderModel(row)
+	err = tx.QueryRow(ctx, `select organization_id from tender where id = $1`, b.TenderID).Scan(&tenderOrganizationID)
+	if err != nil {
+		return model.Bid{}, errors.WithStack(err)
+	}
 
-			err = r.saveTenderVersion(ctx, tx, tender)
-			if err != nil {
-				return model.Bid{}, err
-			}
-		}
+	event := model.AuditEvent{
+		ActorID:        decision.ActorID(),
+		Action:         model.AuditActionDecision,
+		EntityType:     model.EntityBid,
+		EntityID:       b.ID,
+		OrganizationID: tenderOrganizationID,
+	}
+
+	decided := bidDecision{
+		Bid:      b,
+		Decision: decision.Status,
+		Stage:    stagePosition,
+	}
+
+	if decision.DelegateID != uuid.Nil {
+		decided.Approver = &decision.Employee.ID
+	}
+
+	err = r.saveAuditEvent(ctx, tx, event, before, decided)
+	if err != nil {
+		return model.Bid{}, err
 	}
 
 	err = tx.Commit(ctx)
@@ -358,21 +502,189 @@ func (r *Repository) SubmitBidDecision(ctx context.Context, bidID uuid.UUID, emp
 	return b, nil
 }
 
-func (r *Repository) saveBidVersion(ctx context.Context, tx pgx.Tx, bid model.Bid) error {
+// quorumOutcome settles a decision without workflow: approved by the quorum of the organization approvers,
+// rejected by any of them, which wins over a reached quorum. Votes count once per approver whoever cast them,
+// so a delegate votes for each approver they stand in for and an approver is never counted twice.
+func (r *Repository) quorumOutcome(ctx context.Context, tx pgx.Tx, decision model.BidDecision) (model.BidStatus, error) {
+	query := `
+	with q as (select least(count(o.employee_id), $2) count
+	           from bid b
+	                    join tender t on b.tender_id = t.id
+	                    join organization_employee o on t.organization_id = o.organization_id
+	           where b.id = $1
+	             and o.role::text = any ($3))
+	select case
+	           when count(*) filter (where status = 'Rejected') > 0 then 'Rejected'
+	           when count(*) filter (where status = 'Approved') >= (select count from q) then 'Approved'
+	           else ''
+	           end
+	from bid_agreement
+	where bid_id = $1
+	  and lot_id is not distinct from $4
+	  and stage is null`
+
+	var outcome model.BidStatus
+
+	roles := make([]string, 0, len(decision.ApproverRoles))
+	for _, role := range decision.ApproverRoles {
+		roles = append(roles, string(role))
+	}
+
+	err := tx.QueryRow(ctx, query, decision.BidID, minQuorum, roles, nullUUID(decision.LotID)).Scan(&outcome)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return outcome, nil
+}
+
+func (r *Repository) bidForUpdate(ctx context.Context, tx pgx.Tx, bidID uuid.UUID) (model.Bid, error) {
 	query := `
-	insert into bid_version (id, bid_id, name, description, status, created) 
-	values ($1, $2, $3, $4, $5, $6)`
+	select ` + bidColumns + `
+	from bid where id = $1 for update`
 
-	_, err := tx.Exec(ctx, query, bid.VersionID, bid.ID, bid.Name, bid.Description, bid.Status, time.Now())
+	rows, err := tx.Query(ctx, query, bidID)
 	if err != nil {
+		return model.Bid{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[bidRow](rows, pgx.RowToStructByNameLax[bidRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Bid{}, errors.WithStack(model.ErrTenderOrBidNotFound)
+		}
+		return model.Bid{}, errors.WithStack(err)
+	}
+
+	return r.bidModel(row), nil
+}
+
+func (r *Repository) saveBidAudit(ctx context.Context, tx pgx.Tx, actorID uuid.UUID, action model.AuditAction,
+	before any, after model.Bid) error {
+	event := model.AuditEvent{
+		ActorID:        actorID,
+		Action:         action,
+		EntityType:     model.EntityBid,
+		EntityID:       after.ID,
+		OrganizationID: after.OrganizationID,
+	}
+
+	return r.saveAuditEvent(ctx, tx, event, before, after)
+}
+
+// lotOutcome applies a settled decision on one lot and tells what it means for the bid as a whole:
+// the bid is approved once it wins any lot and rejected once it is turned down on all of them.
+func (r *Repository) lotOutcome(ctx context.Context, tx pgx.Tx, bid model.Bid, decision model.BidDecision,
+	outcome model.BidStatus) (model.BidStatus, error) {
+	switch outcome {
+	case model.BidStatusApproved:
+		err := r.awardLot(ctx, tx, bid.TenderID, decision.LotID, bid.ID, decision.ActorID())
+		if err != nil {
+			return "", err
+		}
+
+		return model.BidStatusApproved, nil
+	case model.BidStatusRejected:
+		if bid.Status == model.BidStatusApproved {
+			return "", nil
+		}
+
+		query := `
+		select count(distinct a.lot_id) = (select count(*) from bid_lot where bid_id = $1)
+		from bid_agreement a
+		where a.bid_id = $1
+		  and a.lot_id is not null
+		  and a.status = 'Rejected'`
+
+		var rejected bool
+
+		err := tx.QueryRow(ctx, query, bid.ID).Scan(&rejected)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+
+		if rejected {
+			return model.BidStatusRejected, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (r *Repository) BidVersions(ctx context.Context, bidID uuid.UUID) ([]model.BidVersion, error) {
+	query := `
+	select id, bid_id, name, description, status, price, attachments, created, hash, prev_hash
+	from bid_version
+	where bid_id = $1
+	order by id`
+
+	rows, err := r.pool.Query(ctx, query, bidID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	versionRows, err := pgx.CollectRows[bidVersionRow](rows, pgx.RowToStructByNameLax[bidVersionRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	versions := make([]model.BidVersion, 0, len(versionRows))
+	for _, row := range versionRows {
+		versions = append(versions, r.bidVersionModel(row))
+	}
+
+	return versions, nil
+}
+
+func (r *Repository) saveBidVersion(ctx context.Context, tx pgx.Tx, bid model.Bid) error {
+	v := model.BidVersion{
+		ID:          bid.VersionID,
+		BidID:       bid.ID,
+		Name:        bid.Name,
+		Description: bid.Description,
+		Status:      bid.Status,
+		Price:       bid.Price,
+		Created:     versionTime(),
+	}
+
+	var err error
+
+	v.Attachments, err = r.attachmentSet(ctx, tx, model.EntityBid, bid.ID)
+	if err != nil {
+		return err
+	}
+
+	query := `select hash from bid_version where bid_id = $1 order by id desc limit 1`
+
+	err = tx.QueryRow(ctx, query, bid.ID).Scan(&v.PrevHash)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		return errors.WithStack(err)
 	}
 
-	return nil
+	v.Hash = v.ComputeHash()
+
+	query = `
+	insert into bid_version (id, bid_id, name, description, status, price, attachments, created, hash, prev_hash)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
+
+	_, err = tx.Exec(ctx, query, v.ID, v.BidID, v.Name, v.Description, v.Status, nullFloat(v.Price),
+		nullUUIDs(v.Attachments), v.Created, v.Hash, v.PrevHash)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return r.notifyChange(ctx, tx, model.Change{
+		EntityType: model.EntityBid,
+		EntityID:   bid.ID,
+		TenderID:   bid.TenderID,
+		VersionID:  v.ID,
+		Status:     string(v.Status),
+		Created:    v.Created,
+	})
 }
 
 func (r *Repository) bidModel(row bidRow) model.Bid {
-	return model.Bid{
+	b := model.Bid{
 		ID:             row.ID,
 		Name:           row.Name,
 		Description:    row.Description,
@@ -381,20 +693,89 @@ func (r *Repository) bidModel(row bidRow) model.Bid {
 		CreatorType:    model.CreatorType(row.CreatorType),
 		CreatorID:      row.CreatorID,
 		OrganizationID: row.OrganizationID,
+		LotIDs:         row.LotIDs,
 		VersionID:      row.VersionID,
+		Sealed:         row.Sealed,
+		Blacklisted:    row.Blacklisted,
 		Created:        row.Created,
 	}
+
+	if row.Price != nil {
+		b.Price = *row.Price
+	}
+
+	if row.Score != nil {
+		b.Score = *row.Score
+	}
+
+	if row.Rank != nil {
+		b.Rank = int(*row.Rank)
+	}
+
+	if row.AuthorRating != nil {
+		b.AuthorRating = *row.AuthorRating
+		b.AuthorRated = true
+	}
+
+	return b
+}
+
+type bidDecision struct {
+	model.Bid
+	Decision model.BidStatus
+	Stage    *int       `json:",omitempty"`
+	Approver *uuid.UUID `json:",omitempty"`
 }
 
 type bidRow struct {
-	ID             uuid.UUID `db:"id"`
-	Name           string    `db:"name"`
-	Description    string    `db:"description"`
-	Status         string    `db:"status"`
-	TenderID       uuid.UUID `db:"tender_id"`
-	CreatorType    string    `db:"creator_type"`
-	CreatorID      uuid.UUID `db:"creator_id"`
-	OrganizationID uuid.UUID `db:"organization_id"`
-	VersionID      int64     `db:"version_id"`
-	Created        time.Time `db:"created"`
+	ID             uuid.UUID   `db:"id"`
+	Name           string      `db:"name"`
+	Description    string      `db:"description"`
+	Status         string      `db:"status"`
+	TenderID       uuid.UUID   `db:"tender_id"`
+	CreatorType    string      `db:"creator_type"`
+	CreatorID      uuid.UUID   `db:"creator_id"`
+	OrganizationID uuid.UUID   `db:"organization_id"`
+	LotIDs         []uuid.UUID `db:"lot_ids"`
+	Price          *float64    `db:"price"`
+	VersionID      int64       `db:"version_id"`
+	Sealed         bool        `db:"sealed"`
+	Score          *float64    `db:"score"`
+	Rank           *int64      `db:"rank"`
+	AuthorRating   *float64    `db:"author_rating"`
+	Blacklisted    bool        `db:"blacklisted"`
+	Created        time.Time   `db:"created"`
+}
+
+func (r *Repository) bidVersionModel(row bidVersionRow) model.BidVersion {
+	v := model.BidVersion{
+		ID:          row.ID,
+		BidID:       row.BidID,
+		Name:        row.Name,
+		Description: row.Description,
+		Status:      model.BidStatus(row.Status),
+		Attachments: row.Attachments,
+		Created:     row.Created,
+		Hash:        row.Hash,
+		PrevHash:    row.PrevHash,
+	}
+
+	if row.Price != nil {
+		v.Price = *row.Price
+	}
+
+	return v
+}
+
+type bidVersionRow struct {
+	ID          int64       `db:"id"`
+	BidID       uuid.UUID   `db:"bid_id"`
+	Name        string      `db:"name"`
+	Description string      `db:"description"`
+	Status      string      `db:"status"`
+	Price       *float64    `db:"price"`
+	Attachments []uuid.UUID `db:"attachments"`
+	Created     time.Time   `db:"created"`
+	Hash        string      `db:"hash"`
+	PrevHash    string      `db:"prev_hash"`
 }
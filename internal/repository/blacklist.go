@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const blacklistColumns = `l.id, l.organization_id, l.blocked_organization_id, l.blocked_employee_id,
+	(select e.username from employee e where e.id = l.blocked_employee_id) blocked_username, l.reason, l.expires,
+	l.creator_id, l.created`
+
+// bidBlacklisted tells whether the author of a bid, the bidding organization or the employee, is blacklisted by
+// the tender organization.
+const bidBlacklisted = `exists (select 1
+	        from supplier_blacklist l
+	        where l.organization_id = t.organization_id
+	          and (l.blocked_organization_id = b.organization_id or l.blocked_employee_id = b.creator_id)
+	          and (l.expires is null or l.expires > ?))`
+
+// BlacklistEntries lists the blacklist of the organization, expired entries included.
+func (r *Repository) BlacklistEntries(ctx context.Context, organizationID uuid.UUID) ([]model.BlacklistEntry, error) {
+	query := `
+	select ` + blacklistColumns + `
+	from supplier_blacklist l
+	where l.organization_id = $1
+	order by l.created, l.id`
+
+	rows, err := r.pool.Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	blacklistRows, err := pgx.CollectRows[blacklistRow](rows, pgx.RowToStructByNameLax[blacklistRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	entries := make([]model.BlacklistEntry, 0, len(blacklistRows))
+	for _, row := range blacklistRows {
+		entries = append(entries, r.blacklistModel(row))
+	}
+
+	return entries, nil
+}
+
+// CreateBlacklistEntry blacklists an organization or an employee. Blacklisting them again replaces the reason
+// and the expiry.
+func (r *Repository) CreateBlacklistEntry(ctx context.Context, entry model.BlacklistEntry) (model.BlacklistEntry, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.BlacklistEntry{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	target := "(organization_id, blocked_organization_id)"
+	if entry.BlockedEmployeeID != uuid.Nil {
+		target = "(organization_id, blocked_employee_id)"
+	}
+
+	query := `
+	insert into supplier_blacklist as l (id, organization_id, blocked_organization_id, blocked_employee_id, reason,
+	                                     expires, creator_id, created)
+	values ($1, $2, $3, $4, $5, $6, $7, $8)
+	on conflict ` + target + ` do update set reason     = excluded.reason,
+	                                          expires    = excluded.expires,
+	                                          creator_id = excluded.creator_id,
+	                                          created    = excluded.created
+	returning ` + blacklistColumns
+
+	rows, err := tx.Query(ctx, query, entry.ID, entry.OrganizationID, nullUUID(entry.BlockedOrganizationID),
+		nullUUID(entry.BlockedEmployeeID), entry.Reason, nullTime(entry.Expires), entry.CreatorID, time.Now())
+	if err != nil {
+		return model.BlacklistEntry{}, errors.WithStack(err)
+	}
+
+	l, err := r.collectBlacklistEntry(rows)
+	if err != nil {
+		return model.BlacklistEntry{}, err
+	}
+
+	err = r.saveBlacklistAudit(ctx, tx, entry.CreatorID, model.AuditActionCreate, l, nil, l)
+	if err != nil {
+		return model.BlacklistEntry{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.BlacklistEntry{}, errors.WithStack(err)
+	}
+
+	return l, nil
+}
+
+func (r *Repository) DeleteBlacklistEntry(ctx context.Context, organizationID, entryID, employeeID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	delete
+	from supplier_blacklist l
+	where l.id = $1
+	  and l.organization_id = $2
+	returning ` + blacklistColumns
+
+	rows, err := tx.Query(ctx, query, entryID, organizationID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	l, err := r.collectBlacklistEntry(rows)
+	if err != nil {
+		return err
+	}
+
+	err = r.saveBlacklistAudit(ctx, tx, employeeID, model.AuditActionDelete, l, l, nil)
+	if err != nil {
+		return err
+	}
+
+	return errors.WithStack(tx.Commit(ctx))
+}
+
+// checkBlacklist refuses bids on the tenders of an organization from the organizations and employees
+// it has blacklisted.
+func (r *Repository) checkBlacklist(ctx context.Context, tx pgx.Tx, bid model.Bid) error {
+	query := `
+	select exists (select 1
+	               from supplier_blacklist l
+	                        join tender t on t.organization_id = l.organization_id
+	               where t.id = $1
+	                 and (l.blocked_organization_id = $2 or l.blocked_employee_id = $3)
+	                 and (l.expires is null or l.expires > $4))`
+
+	var blacklisted bool
+	err := tx.QueryRow(ctx, query, bid.TenderID, bid.OrganizationID, bid.CreatorID, time.Now()).Scan(&blacklisted)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if blacklisted {
+		return errors.WithStack(model.ErrBlacklisted)
+	}
+
+	return nil
+}
+
+func (r *Repository) saveBlacklistAudit(ctx context.Context, tx pgx.Tx, actorID uuid.UUID, action model.AuditAction,
+	entry model.BlacklistEntry, before, after any) error {
+	event := model.AuditEvent{
+		ActorID:        actorID,
+		Action:         action,
+		EntityType:     model.EntityBlacklist,
+		EntityID:       entry.ID,
+		OrganizationID: entry.OrganizationID,
+	}
+
+	return r.saveAuditEvent(ctx, tx, event, before, after)
+}
+
+func (r *Repository) collectBlacklistEntry(rows pgx.Rows) (model.BlacklistEntry, error) {
+	row, err := pgx.CollectExactlyOneRow[blacklistRow](rows, pgx.RowToStructByNameLax[blacklistRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.BlacklistEntry{}, errors.WithStack(model.ErrBlacklistEntryNotFound)
+		}
+		return model.BlacklistEntry{}, errors.WithStack(err)
+	}
+
+	return r.blacklistModel(row), nil
+}
+
+func (r *Repository) blacklistModel(row blacklistRow) model.BlacklistEntry {
+	l := model.BlacklistEntry{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		Reason:         row.Reason,
+		CreatorID:      row.CreatorID,
+		Created:        row.Created,
+	}
+
+	if row.BlockedOrganizationID != nil {
+		l.BlockedOrganizationID = *row.BlockedOrganizationID
+	}
+
+	if row.BlockedEmployeeID != nil {
+		l.BlockedEmployeeID = *row.BlockedEmployeeID
+	}
+
+	if row.BlockedUsername != nil {
+		l.BlockedUsername = *row.BlockedUsername
+	}
+
+	if row.Expires != nil {
+		l.Expires = *row.Expires
+	}
+
+	return l
+}
+
+type blacklistRow struct {
+	ID                    uuid.UUID  `db:"id"`
+	OrganizationID        uuid.UUID  `db:"organization_id"`
+	BlockedOrganizationID *uuid.UUID `db:"blocked_organization_id"`
+	BlockedEmployeeID     *uuid.UUID `db:"blocked_employee_id"`
+	BlockedUsername       *string    `db:"blocked_username"`
+	Reason                string     `db:"reason"`
+	Expires               *time.Time `db:"expires"`
+	CreatorID             uuid.UUID  `db:"creator_id"`
+	Created               time.Time  `db:"created"`
+}
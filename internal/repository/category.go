@@ -0,0 +1,329 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const categoryColumns = `id, parent_id, name, created`
+
+// Categories lists the whole taxonomy, parents before their children.
+func (r *Repository) Categories(ctx context.Context) ([]model.Category, error) {
+	query := `
+	with recursive tree as (select ` + categoryColumns + `, array [name] path
+	                        from category
+	                        where parent_id is null
+	                        union all
+	                        select c.id, c.parent_id, c.name, c.created, t.path || c.name
+	                        from category c
+	                                 join tree t on c.parent_id = t.id)
+	select ` + categoryColumns + `
+	from tree
+	order by path`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	categoryRows, err := pgx.CollectRows[categoryRow](rows, pgx.RowToStructByNameLax[categoryRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	categories := make([]model.Category, 0, len(categoryRows))
+	for _, row := range categoryRows {
+		categories = append(categories, r.categoryModel(row))
+	}
+
+	return categories, nil
+}
+
+func (r *Repository) Category(ctx context.Context, categoryID uuid.UUID) (model.Category, error) {
+	rows, err := r.pool.Query(ctx, `select `+categoryColumns+` from category where id = $1`, categoryID)
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+
+	return r.collectCategory(rows)
+}
+
+// RootCategory finds a top level category by name, which is how the former service types are still addressed.
+func (r *Repository) RootCategory(ctx context.Context, name string) (model.Category, error) {
+	rows, err := r.pool.Query(ctx, `select `+categoryColumns+` from category where parent_id is null and name = $1`, name)
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+
+	return r.collectCategory(rows)
+}
+
+func (r *Repository) CreateCategory(ctx context.Context, category model.Category, employeeID uuid.UUID) (model.Category, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if category.ParentID != uuid.Nil {
+		_, err = r.categoryForUpdate(ctx, tx, category.ParentID)
+		if err != nil {
+			return model.Category{}, err
+		}
+	}
+
+	err = r.checkCategoryName(ctx, tx, category)
+	if err != nil {
+		return model.Category{}, err
+	}
+
+	query := `
+	insert into category (id, parent_id, name, created)
+	values ($1, $2, $3, $4)
+	returning ` + categoryColumns
+
+	rows, err := tx.Query(ctx, query, category.ID, nullUUID(category.ParentID), category.Name, time.Now())
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+
+	c, err := r.collectCategory(rows)
+	if err != nil {
+		return model.Category{}, err
+	}
+
+	err = r.saveCategoryAudit(ctx, tx, employeeID, model.AuditActionCreate, nil, c)
+	if err != nil {
+		return model.Category{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+
+	return c, nil
+}
+
+// UpdateCategory renames a category or moves it under another parent. A parent inside the subtree
+// of the category would make a cycle and is refused.
+func (r *Repository) UpdateCategory(ctx context.Context, category model.Category, employeeID uuid.UUID) (model.Category, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := r.categoryForUpdate(ctx, tx, category.ID)
+	if err != nil {
+		return model.Category{}, err
+	}
+
+	if category.ParentID != uuid.Nil {
+		_, err = r.categoryForUpdate(ctx, tx, category.ParentID)
+		if err != nil {
+			return model.Category{}, err
+		}
+
+		query := `
+		with recursive subtree as (select id
+		                           from category
+		                           where id = $1
+		                           union all
+		                           select c.id
+		                           from category c
+		                                    join subtree s on c.parent_id = s.id)
+		select exists (select 1 from subtree where id = $2)`
+
+		var cycle bool
+
+		err = tx.QueryRow(ctx, query, category.ID, category.ParentID).Scan(&cycle)
+		if err != nil {
+			return model.Category{}, errors.WithStack(err)
+		}
+
+		if cycle {
+			return model.Category{}, errors.WithStack(model.ErrInvalidCategory)
+		}
+	}
+
+	after := before
+	if category.Name != "" {
+		after.Name = category.Name
+	}
+	if category.ParentID != uuid.Nil {
+		after.ParentID = category.ParentID
+	}
+
+	err = r.checkCategoryName(ctx, tx, after)
+	if err != nil {
+		return model.Category{}, err
+	}
+
+	query, args, err := r.builder.Update("category").
+		Set("name", after.Name).
+		Set("parent_id", nullUUID(after.ParentID)).
+		Where(sq.Eq{"id": after.ID}).
+		Suffix("returning " + categoryColumns).
+		ToSql()
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+
+	c, err := r.collectCategory(rows)
+	if err != nil {
+		return model.Category{}, err
+	}
+
+	err = r.saveCategoryAudit(ctx, tx, employeeID, model.AuditActionEdit, before, c)
+	if err != nil {
+		return model.Category{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+
+	return c, nil
+}
+
+// DeleteCategory removes a category nothing refers to any more, tender versions included, so history keeps
+// pointing at real categories.
+func (r *Repository) DeleteCategory(ctx context.Context, categoryID, employeeID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := r.categoryForUpdate(ctx, tx, categoryID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	select exists (select 1 from category where parent_id = $1)
+	           or exists (select 1 from tender where category_id = $1)
+	           or exists (select 1 from tender_version where category_id = $1)
+	           or exists (select 1 from tender_template where category_id = $1)`
+
+	var used bool
+
+	err = tx.QueryRow(ctx, query, categoryID).Scan(&used)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if used {
+		return errors.WithStack(model.ErrCategoryInUse)
+	}
+
+	_, err = tx.Exec(ctx, `delete from category where id = $1`, categoryID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = r.saveCategoryAudit(ctx, tx, employeeID, model.AuditActionDelete, before, nil)
+	if err != nil {
+		return err
+	}
+
+	return errors.WithStack(tx.Commit(ctx))
+}
+
+func (r *Repository) categoryForUpdate(ctx context.Context, tx pgx.Tx, categoryID uuid.UUID) (model.Category, error) {
+	rows, err := tx.Query(ctx, `select `+categoryColumns+` from category where id = $1 for update`, categoryID)
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+
+	return r.collectCategory(rows)
+}
+
+// checkCategoryName makes sure no sibling of the category already has its name.
+func (r *Repository) checkCategoryName(ctx context.Context, tx pgx.Tx, category model.Category) error {
+	query := `
+	select exists (select 1
+	               from category
+	               where parent_id is not distinct from $1
+	                 and name = $2
+	                 and id <> $3)`
+
+	var taken bool
+
+	err := tx.QueryRow(ctx, query, nullUUID(category.ParentID), category.Name, category.ID).Scan(&taken)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if taken {
+		return errors.WithStack(model.ErrInvalidCategory)
+	}
+
+	return nil
+}
+
+func (r *Repository) saveCategoryAudit(ctx context.Context, tx pgx.Tx, actorID uuid.UUID, action model.AuditAction,
+	before, after any) error {
+	var category model.Category
+	if c, ok := after.(model.Category); ok {
+		category = c
+	} else if c, ok := before.(model.Category); ok {
+		category = c
+	}
+
+	event := model.AuditEvent{
+		ActorID:    actorID,
+		Action:     action,
+		EntityType: model.EntityCategory,
+		EntityID:   category.ID,
+	}
+
+	return r.saveAuditEvent(ctx, tx, event, before, after)
+}
+
+func (r *Repository) collectCategory(rows pgx.Rows) (model.Category, error) {
+	row, err := pgx.CollectExactlyOneRow[categoryRow](rows, pgx.RowToStructByNameLax[categoryRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Category{}, errors.WithStack(model.ErrCategoryNotFound)
+		}
+		return model.Category{}, errors.WithStack(err)
+	}
+
+	return r.categoryModel(row), nil
+}
+
+func (r *Repository) categoryModel(row categoryRow) model.Category {
+	c := model.Category{
+		ID:      row.ID,
+		Name:    row.Name,
+		Created: row.Created,
+	}
+
+	if row.ParentID != nil {
+		c.ParentID = *row.ParentID
+	}
+
+	return c
+}
+
+type categoryRow struct {
+	ID       uuid.UUID  `db:"id"`
+	ParentID *uuid.UUID `db:"parent_id"`
+	Name     string     `db:"name"`
+	Created  time.Time  `db:"created"`
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const changeChannel = "tender_changes"
+
+// ListenChanges blocks on a dedicated connection and hands every committed change to handle until ctx is done
+// or the connection breaks. Notifications are sent from the writing transaction, so they are only delivered
+// on commit, and every replica listening on the channel receives them.
+func (r *Repository) ListenChanges(ctx context.Context, handle func(model.Change)) error {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, "listen "+changeChannel)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		var change model.Change
+
+		err = json.Unmarshal([]byte(n.Payload), &change)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		handle(change)
+	}
+}
+
+// ChangeScope looks up the tender, its invitations and, for a bid change, the bid the change is about.
+func (r *Repository) ChangeScope(ctx context.Context, change model.Change) (model.ChangeScope, error) {
+	var bidID uuid.UUID
+	if change.EntityType == model.EntityBid {
+		bidID = change.EntityID
+	}
+
+	query := `
+	select t.organization_id                                                 tender_organization_id,
+	       t.status = 'Published'                                            tender_published,
+	       t.visibility = 'Public'                                           tender_public,
+	       array(select i.organization_id
+	             from tender_invitation i
+	             where i.tender_id = t.id
+	               and i.status <> 'Declined'
+	               and i.organization_id is not null)                        invited_organization_ids,
+	       array(select i.employee_id
+	             from tender_invitation i
+	             where i.tender_id = t.id
+	               and i.status <> 'Declined'
+	               and i.employee_id is not null)                            invited_employee_ids,
+	       b.organization_id                                                 bid_organization_id,
+	       b.status                                                          bid_status
+	from tender t
+	         left join bid b on b.id = $2 and b.tender_id = t.id
+	where t.id = $1`
+
+	rows, err := r.pool.Query(ctx, query, change.TenderID, nullUUID(bidID))
+	if err != nil {
+		return model.ChangeScope{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[changeScopeRow](rows, pgx.RowToStructByNameLax[changeScopeRow])
+	if err != nil {
+		return model.ChangeScope{}, errors.WithStack(err)
+	}
+
+	scope := model.ChangeScope{
+		TenderOrganizationID:   row.TenderOrganizationID,
+		TenderPublished:        row.TenderPublished,
+		TenderPublic:           row.TenderPublic,
+		InvitedOrganizationIDs: row.InvitedOrganizationIDs,
+		InvitedEmployeeIDs:     row.InvitedEmployeeIDs,
+	}
+
+	if row.BidOrganizationID != nil {
+		scope.BidOrganizationID = *row.BidOrganizationID
+	}
+
+	if row.BidStatus != nil {
+		scope.BidStatus = model.BidStatus(*row.BidStatus)
+	}
+
+	return scope, nil
+}
+
+func (r *Repository) notifyChange(ctx context.Context, tx pgx.Tx, change model.Change) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = tx.Exec(ctx, "select pg_notify($1, $2)", changeChannel, string(payload))
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+type changeScopeRow struct {
+	TenderOrganizationID   uuid.UUID   `db:"tender_organization_id"`
+	TenderPublished        bool        `db:"tender_published"`
+	TenderPublic           bool        `db:"tender_public"`
+	InvitedOrganizationIDs []uuid.UUID `db:"invited_organization_ids"`
+	InvitedEmployeeIDs     []uuid.UUID `db:"invited_employee_ids"`
+	BidOrganizationID      *uuid.UUID  `db:"bid_organization_id"`
+	BidStatus              *string     `db:"bid_status"`
+}
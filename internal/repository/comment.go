@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const commentColumns = `c.id, c.bid_id, c.bid_version_id, c.parent_id, c.author_id, c.body, c.created,
+	array(select m.employee_id from bid_comment_mention m where m.comment_id = c.id order by m.employee_id) as mentions`
+
+func (r *Repository) Comments(ctx context.Context, opts model.CommentFilter) ([]model.Comment, error) {
+	b := r.builder.
+		Select(commentColumns).
+		Column(sq.Expr("c.author_id = ? or c.created <= rd.read_until as read", opts.EmployeeID)).
+		From("bid_comment c").
+		LeftJoin("bid_comment_read rd on rd.bid_id = c.bid_id and rd.employee_id = ?", opts.EmployeeID).
+		Where(sq.Eq{"c.bid_id": opts.BidID})
+
+	if opts.Offset > 0 {
+		b = b.Offset(opts.Offset)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	b = b.OrderBy("c.created", "c.id").Limit(limit)
+
+	query, args, err := b.ToSql()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	commentRows, err := pgx.CollectRows[commentRow](rows, pgx.RowToStructByNameLax[commentRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	comments := make([]model.Comment, 0, len(commentRows))
+	for _, row := range commentRows {
+		comments = append(comments, r.commentModel(row))
+	}
+
+	return comments, nil
+}
+
+// CreateComment adds a comment to the thread of a bid. Without a version it refers to the current one.
+// Only mentioned usernames that take part in the discussion, members of the bid or the tender organization,
+// are kept.
+func (r *Repository) CreateComment(ctx context.Context, comment model.Comment, mentions []string) (model.Comment, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Comment{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	insert into bid_comment (id, bid_id, bid_version_id, parent_id, author_id, body, created)
+	select $1, b.id, coalesce($3, b.version_id), $4, $5, $6, $7
+	from bid b
+	where b.id = $2
+	  and exists (select 1 from bid_version v where v.bid_id = b.id and v.id = coalesce($3, b.version_id))
+	  and ($4::uuid is null or exists (select 1 from bid_comment p where p.id = $4 and p.bid_id = b.id))`
+
+	var versionID *int64
+	if comment.BidVersionID > 0 {
+		versionID = &comment.BidVersionID
+	}
+
+	now := time.Now()
+
+	tag, err := tx.Exec(ctx, query, comment.ID, comment.BidID, versionID, nullUUID(comment.ParentID), comment.AuthorID,
+		comment.Body, now)
+	if err != nil {
+		return model.Comment{}, errors.WithStack(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return model.Comment{}, errors.WithStack(model.ErrInvalidComment)
+	}
+
+	if len(mentions) > 0 {
+		query = `
+		insert into bid_comment_mention (comment_id, employee_id)
+		select $1, e.id
+		from employee e
+		         join bid b on b.id = $3
+		         join tender t on t.id = b.tender_id
+		where e.username = any ($2)
+		  and (e.id = b.creator_id or exists (select 1
+		                                     from organization_employee o
+		                                     where o.employee_id = e.id
+		                                       and o.organization_id in (b.organization_id, t.organization_id)))
+		on conflict do nothing`
+
+		_, err = tx.Exec(ctx, query, comment.ID, mentions, comment.BidID)
+		if err != nil {
+			return model.Comment{}, errors.WithStack(err)
+		}
+	}
+
+	// Writing a comment means the author has seen the thread up to it.
+	err = r.markCommentsRead(ctx, tx, comment.BidID, comment.AuthorID, now)
+	if err != nil {
+		return model.Comment{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Comment{}, errors.WithStack(err)
+	}
+
+	return r.comment(ctx, comment.ID, comment.AuthorID)
+}
+
+// MarkCommentsRead moves the read marker of the employee on a bid thread to now.
+func (r *Repository) MarkCommentsRead(ctx context.Context, bidID, employeeID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	err = r.markCommentsRead(ctx, tx, bidID, employeeID, time.Now())
+	if err != nil {
+		return err
+	}
+
+	return errors.WithStack(tx.Commit(ctx))
+}
+
+func (r *Repository) markCommentsRead(ctx context.Context, tx pgx.Tx, bidID, employeeID uuid.UUID, until time.Time) error {
+	query := `
+	insert into bid_comment_read (bid_id, employee_id, read_until)
+	values ($1, $2, $3)
+	on conflict (bid_id, employee_id) do update set read_until = greatest(bid_comment_read.read_until, excluded.read_until)`
+
+	_, err := tx.Exec(ctx, query, bidID, employeeID, until)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) comment(ctx context.Context, commentID, employeeID uuid.UUID) (model.Comment, error) {
+	query := `
+	select ` + commentColumns + `, c.author_id = $2 or c.created <= rd.read_until as read
+	from bid_comment c
+	         left join bid_comment_read rd on rd.bid_id = c.bid_id and rd.employee_id = $2
+	where c.id = $1`
+
+	rows, err := r.pool.Query(ctx, query, commentID, employeeID)
+	if err != nil {
+		return model.Comment{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[commentRow](rows, pgx.RowToStructByNameLax[commentRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Comment{}, errors.WithStack(model.ErrCommentNotFound)
+		}
+		return model.Comment{}, errors.WithStack(err)
+	}
+
+	return r.commentModel(row), nil
+}
+
+func (r *Repository) commentModel(row commentRow) model.Comment {
+	c := model.Comment{
+		ID:           row.ID,
+		BidID:        row.BidID,
+		BidVersionID: row.BidVersionID,
+		AuthorID:     row.AuthorID,
+		Body:         row.Body,
+		Mentions:     row.Mentions,
+		Created:      row.Created,
+	}
+
+	if row.ParentID != nil {
+		c.ParentID = *row.ParentID
+	}
+
+	if row.Read != nil {
+		c.Read = *row.Read
+	}
+
+	return c
+}
+
+type commentRow struct {
+	ID           uuid.UUID   `db:"id"`
+	BidID        uuid.UUID   `db:"bid_id"`
+	BidVersionID int64       `db:"bid_version_id"`
+	ParentID     *uuid.UUID  `db:"parent_id"`
+	AuthorID     uuid.UUID   `db:"author_id"`
+	Body         string      `db:"body"`
+	Mentions     []uuid.UUID `db:"mentions"`
+	Read         *bool       `db:"read"`
+	Created      time.Time   `db:"created"`
+}
@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+// BidTallies counts the votes and averages the scores of every bid of a tender.
+func (r *Repository) BidTallies(ctx context.Context, tenderID uuid.UUID) ([]model.BidTally, error) {
+	query := `
+	select b.id                                                 bid_id,
+	       count(a.bid_id) filter (where a.status = 'Approved') approvals,
+	       count(a.bid_id) filter (where a.status = 'Rejected') rejections
+	from bid b
+	         left join bid_agreement a on a.bid_id = b.id
+	where b.tender_id = $1
+	group by b.id
+	order by b.id`
+
+	rows, err := r.pool.Query(ctx, query, tenderID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	tallyRows, err := pgx.CollectRows[tallyRow](rows, pgx.RowToStructByNameLax[tallyRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	tallies := make([]model.BidTally, 0, len(tallyRows))
+	index := make(map[uuid.UUID]int, len(tallyRows))
+
+	for i, row := range tallyRows {
+		index[row.BidID] = i
+		tallies = append(tallies, model.BidTally{
+			BidID:      row.BidID,
+			Approvals:  row.Approvals,
+			Rejections: row.Rejections,
+			Scores:     make(map[uuid.UUID]float64),
+		})
+	}
+
+	query = `
+	select s.bid_id, s.criterion_id, avg(s.score) score
+	from bid_score s
+	         join bid b on s.bid_id = b.id
+	where b.tender_id = $1
+	group by s.bid_id, s.criterion_id`
+
+	rows, err = r.pool.Query(ctx, query, tenderID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	scoreRows, err := pgx.CollectRows[scoreRow](rows, pgx.RowToStructByNameLax[scoreRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, row := range scoreRows {
+		if i, ok := index[row.BidID]; ok {
+			tallies[i].Scores[row.CriterionID] = row.Score
+		}
+	}
+
+	return tallies, nil
+}
+
+type tallyRow struct {
+	BidID      uuid.UUID `db:"bid_id"`
+	Approvals  int       `db:"approvals"`
+	Rejections int       `db:"rejections"`
+}
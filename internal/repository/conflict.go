@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const exemptionColumns = `x.id, x.organization_id, x.employee_id,
+	(select e.username from employee e where e.id = x.employee_id) username, x.reason, x.creator_id, x.created`
+
+func (r *Repository) ConflictExemptions(ctx context.Context, organizationID uuid.UUID) ([]model.ConflictExemption, error) {
+	query := `
+	select ` + exemptionColumns + `
+	from conflict_exemption x
+	where x.organization_id = $1
+	order by x.created, x.id`
+
+	rows, err := r.pool.Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	exemptionRows, err := pgx.CollectRows[exemptionRow](rows, pgx.RowToStructByNameLax[exemptionRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	exemptions := make([]model.ConflictExemption, 0, len(exemptionRows))
+	for _, row := range exemptionRows {
+		exemptions = append(exemptions, r.exemptionModel(row))
+	}
+
+	return exemptions, nil
+}
+
+func (r *Repository) ConflictExempt(ctx context.Context, organizationID, employeeID uuid.UUID) (bool, error) {
+	query := `
+	select exists (select 1 from conflict_exemption where organization_id = $1 and employee_id = $2)`
+
+	var exempt bool
+
+	err := r.pool.QueryRow(ctx, query, organizationID, employeeID).Scan(&exempt)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return exempt, nil
+}
+
+// CreateConflictExemption adds the employee to the exemption list of the organization. Exempting an employee
+// again replaces the reason.
+func (r *Repository) CreateConflictExemption(ctx context.Context,
+	exemption model.ConflictExemption) (model.ConflictExemption, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.ConflictExemption{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	insert into conflict_exemption as x (id, organization_id, employee_id, reason, creator_id, created)
+	values ($1, $2, $3, $4, $5, $6)
+	on conflict (organization_id, employee_id) do update set reason     = excluded.reason,
+	                                                         creator_id = excluded.creator_id,
+	                                                         created    = excluded.created
+	returning ` + exemptionColumns
+
+	rows, err := tx.Query(ctx, query, exemption.ID, exemption.OrganizationID, exemption.EmployeeID, exemption.Reason,
+		exemption.CreatorID, time.Now())
+	if err != nil {
+		return model.ConflictExemption{}, errors.WithStack(err)
+	}
+
+	x, err := r.collectExemption(rows)
+	if err != nil {
+		return model.ConflictExemption{}, err
+	}
+
+	err = r.saveExemptionAudit(ctx, tx, exemption.CreatorID, model.AuditActionCreate, x, nil, x)
+	if err != nil {
+		return model.ConflictExemption{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.ConflictExemption{}, errors.WithStack(err)
+	}
+
+	return x, nil
+}
+
+func (r *Repository) DeleteConflictExemption(ctx context.Context, organizationID, exemptionID, employeeID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	delete
+	from conflict_exemption x
+	where x.id = $1
+	  and x.organization_id = $2
+	returning ` + exemptionColumns
+
+	rows, err := tx.Query(ctx, query, exemptionID, organizationID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	x, err := r.collectExemption(rows)
+	if err != nil {
+		return err
+	}
+
+	err = r.saveExemptionAudit(ctx, tx, employeeID, model.AuditActionDelete, x, x, nil)
+	if err != nil {
+		return err
+	}
+
+	return errors.WithStack(tx.Commit(ctx))
+}
+
+// RecordConflict writes the audit entry of a blocked attempt. It runs in a transaction of its own, the attempt
+// itself never gets to one.
+func (r *Repository) RecordConflict(ctx context.Context, conflict model.Conflict) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	event := model.AuditEvent{
+		ActorID:        conflict.EmployeeID,
+		Action:         model.AuditActionBlock,
+		EntityType:     model.EntityTender,
+		EntityID:       conflict.TenderID,
+		OrganizationID: conflict.TenderOrganizationID,
+	}
+
+	if conflict.BidID != uuid.Nil {
+		event.EntityType = model.EntityBid
+		event.EntityID = conflict.BidID
+	}
+
+	err = r.saveAuditEvent(ctx, tx, event, nil, conflict)
+	if err != nil {
+		return err
+	}
+
+	return errors.WithStack(tx.Commit(ctx))
+}
+
+func (r *Repository) saveExemptionAudit(ctx context.Context, tx pgx.Tx, actorID uuid.UUID, action model.AuditAction,
+	exemption model.ConflictExemption, before, after any) error {
+	event := model.AuditEvent{
+		ActorID:        actorID,
+		Action:         action,
+		EntityType:     model.EntityExemption,
+		EntityID:       exemption.ID,
+		OrganizationID: exemption.OrganizationID,
+	}
+
+	return r.saveAuditEvent(ctx, tx, event, before, after)
+}
+
+func (r *Repository) collectExemption(rows pgx.Rows) (model.ConflictExemption, error) {
+	row, err := pgx.CollectExactlyOneRow[exemptionRow](rows, pgx.RowToStructByNameLax[exemptionRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ConflictExemption{}, errors.WithStack(model.ErrExemptionNotFound)
+		}
+		return model.ConflictExemption{}, errors.WithStack(err)
+	}
+
+	return r.exemptionModel(row), nil
+}
+
+func (r *Repository) exemptionModel(row exemptionRow) model.ConflictExemption {
+	return model.ConflictExemption{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		EmployeeID:     row.EmployeeID,
+		Username:       row.Username,
+		Reason:         row.Reason,
+		CreatorID:      row.CreatorID,
+		Created:        row.Created,
+	}
+}
+
+type exemptionRow struct {
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	EmployeeID     uuid.UUID `db:"employee_id"`
+	Username       string    `db:"username"`
+	Reason         string    `db:"reason"`
+	CreatorID      uuid.UUID `db:"creator_id"`
+	Created        time.Time `db:"created"`
+}
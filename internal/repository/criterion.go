@@ -0,0 +1,312 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+// bidScore is the weighted total of a bid: every criterion counts with the average of the approvers' scores,
+// criteria nobody scored yet count as zero. Bids without any score have no total.
+const bidScore = `(select sum(c.weight * coalesce(s.score, 0)) / sum(c.weight)
+	from tender_criterion c
+	         left join lateral (select avg(score) score
+	                            from bid_score
+	                            where bid_id = b.id
+	                              and criterion_id = c.id) s on true
+	where c.tender_id = b.tender_id
+	having count(s.score) > 0)`
+
+// bidRank ranks a bid by score among all the submitted bids of its tender, whichever bids the query returns.
+// The inner b is the ranked bid, the outer b and t are the bid and tender of the query.
+const bidRank = `(select r.rank
+	from (select b.id, rank() over (order by ` + bidScore + ` desc nulls last) rank
+	      from bid b
+	      where b.tender_id = t.id
+	        and b.status <> 'Created') r
+	where r.id = b.id)`
+
+func (r *Repository) Criteria(ctx context.Context, tenderID uuid.UUID) ([]model.Criterion, error) {
+	query := `
+	select id, tender_id, name, weight, created
+	from tender_criterion
+	where tender_id = $1
+	order by created, id`
+
+	rows, err := r.pool.Query(ctx, query, tenderID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	criterionRows, err := pgx.CollectRows[criterionRow](rows, pgx.RowToStructByNameLax[criterionRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	criteria := make([]model.Criterion, 0, len(criterionRows))
+	for _, row := range criterionRows {
+		criteria = append(criteria, r.criterionModel(row))
+	}
+
+	return criteria, nil
+}
+
+// ReplaceCriteria swaps the whole criteria set of a tender, which is only possible before the first score.
+func (r *Repository) ReplaceCriteria(ctx context.Context, tenderID uuid.UUID, criteria []model.Criterion,
+	employeeID uuid.UUID) ([]model.Criterion, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tender, err := r.tenderForUpdate(ctx, tx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+	select exists (select 1
+	               from bid_score s
+	                        join tender_criterion c on s.criterion_id = c.id
+	               where c.tender_id = $1)`
+
+	var scored bool
+
+	err = tx.QueryRow(ctx, query, tenderID).Scan(&scored)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if scored {
+		return nil, errors.WithStack(model.ErrCriteriaScored)
+	}
+
+	before, err := r.criteria(ctx, tx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `delete from tender_criterion where tender_id = $1`, tenderID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	now := time.Now()
+
+	for _, c := range criteria {
+		query = `
+		insert into tender_criterion (id, tender_id, name, weight, created)
+		values ($1, $2, $3, $4, $5)`
+
+		_, err = tx.Exec(ctx, query, c.ID, tenderID, c.Name, c.Weight, now)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	after, err := r.criteria(ctx, tx, tenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	event := model.AuditEvent{
+		ActorID:        employeeID,
+		Action:         model.AuditActionCriteria,
+		EntityType:     model.EntityTender,
+		EntityID:       tenderID,
+		OrganizationID: tender.OrganizationID,
+	}
+
+	err = r.saveAuditEvent(ctx, tx, event, before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return after, nil
+}
+
+func (r *Repository) Scores(ctx context.Context, bidID uuid.UUID) ([]model.Score, error) {
+	query := `
+	select bid_id, criterion_id, employee_id, score, updated
+	from bid_score
+	where bid_id = $1
+	order by employee_id, criterion_id`
+
+	rows, err := r.pool.Query(ctx, query, bidID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return r.collectScores(rows)
+}
+
+// SaveScores records the scores of one approver on a bid, replacing the marks they gave before.
+func (r *Repository) SaveScores(ctx context.Context, bidID, employeeID uuid.UUID, scores []model.Score) ([]model.Score, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	bid, err := r.bidForUpdate(ctx, tx, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := r.employeeScores(ctx, tx, bidID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	for _, s := range scores {
+		query := `
+		insert into bid_score (bid_id, criterion_id, employee_id, score, updated)
+		select $1, c.id, $3, $4, $5
+		from tender_criterion c
+		where c.id = $2
+		  and c.tender_id = $6
+		on conflict (bid_id, criterion_id, employee_id) do update set score   = excluded.score,
+		                                                              updated = excluded.updated`
+
+		tag, err := tx.Exec(ctx, query, bidID, s.CriterionID, employeeID, s.Score, now, bid.TenderID)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if tag.RowsAffected() == 0 {
+			return nil, errors.WithStack(model.ErrInvalidScore)
+		}
+	}
+
+	after, err := r.employeeScores(ctx, tx, bidID, employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tenderOrganizationID uuid.UUID
+
+	err = tx.QueryRow(ctx, `select organization_id from tender where id = $1`, bid.TenderID).Scan(&tenderOrganizationID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	event := model.AuditEvent{
+		ActorID:        employeeID,
+		Action:         model.AuditActionScore,
+		EntityType:     model.EntityBid,
+		EntityID:       bidID,
+		OrganizationID: tenderOrganizationID,
+	}
+
+	err = r.saveAuditEvent(ctx, tx, event, before, after)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return after, nil
+}
+
+func (r *Repository) criteria(ctx context.Context, tx pgx.Tx, tenderID uuid.UUID) ([]model.Criterion, error) {
+	query := `
+	select id, tender_id, name, weight, created
+	from tender_criterion
+	where tender_id = $1
+	order by created, id`
+
+	rows, err := tx.Query(ctx, query, tenderID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	criterionRows, err := pgx.CollectRows[criterionRow](rows, pgx.RowToStructByNameLax[criterionRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	criteria := make([]model.Criterion, 0, len(criterionRows))
+	for _, row := range criterionRows {
+		criteria = append(criteria, r.criterionModel(row))
+	}
+
+	return criteria, nil
+}
+
+func (r *Repository) employeeScores(ctx context.Context, tx pgx.Tx, bidID, employeeID uuid.UUID) ([]model.Score, error) {
+	query := `
+	select bid_id, criterion_id, employee_id, score, updated
+	from bid_score
+	where bid_id = $1
+	  and employee_id = $2
+	order by criterion_id`
+
+	rows, err := tx.Query(ctx, query, bidID, employeeID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return r.collectScores(rows)
+}
+
+func (r *Repository) collectScores(rows pgx.Rows) ([]model.Score, error) {
+	scoreRows, err := pgx.CollectRows[scoreRow](rows, pgx.RowToStructByNameLax[scoreRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	scores := make([]model.Score, 0, len(scoreRows))
+	for _, row := range scoreRows {
+		scores = append(scores, model.Score{
+			BidID:       row.BidID,
+			CriterionID: row.CriterionID,
+			EmployeeID:  row.EmployeeID,
+			Score:       row.Score,
+			Updated:     row.Updated,
+		})
+	}
+
+	return scores, nil
+}
+
+func (r *Repository) criterionModel(row criterionRow) model.Criterion {
+	return model.Criterion{
+		ID:       row.ID,
+		TenderID: row.TenderID,
+		Name:     row.Name,
+		Weight:   row.Weight,
+		Created:  row.Created,
+	}
+}
+
+type criterionRow struct {
+	ID       uuid.UUID `db:"id"`
+	TenderID uuid.UUID `db:"tender_id"`
+	Name     string    `db:"name"`
+	Weight   float64   `db:"weight"`
+	Created  time.Time `db:"created"`
+}
+
+type scoreRow struct {
+	BidID       uuid.UUID `db:"bid_id"`
+	CriterionID uuid.UUID `db:"criterion_id"`
+	EmployeeID  uuid.UUID `db:"employee_id"`
+	Score       float64   `db:"score"`
+	Updated     time.Time `db:"updated"`
+}
@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const delegationColumns = `d.id, d.organization_id, d.delegator_id,
+	(select e.username from employee e where e.id = d.delegator_id) delegator, d.delegate_id,
+	(select e.username from employee e where e.id = d.delegate_id) delegate, d.starts, d.ends, d.creator_id, d.created`
+
+// Delegations lists the delegations of the organization that have not ended yet.
+func (r *Repository) Delegations(ctx context.Context, organizationID uuid.UUID) ([]model.Delegation, error) {
+	query := `
+	select ` + delegationColumns + `
+	from approval_delegation d
+	where d.organization_id = $1
+	  and d.ends > $2
+	order by d.starts, d.id`
+
+	rows, err := r.pool.Query(ctx, query, organizationID, time.Now())
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	delegationRows, err := pgx.CollectRows[delegationRow](rows, pgx.RowToStructByNameLax[delegationRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	delegations := make([]model.Delegation, 0, len(delegationRows))
+	for _, row := range delegationRows {
+		delegations = append(delegations, r.delegationModel(row))
+	}
+
+	return delegations, nil
+}
+
+// Delegated tells whether the delegate may vote on behalf of the approver in the organization at the moment.
+func (r *Repository) Delegated(ctx context.Context, organizationID, delegatorID, delegateID uuid.UUID,
+	at time.Time) (bool, error) {
+	query := `
+	select exists (select 1
+	               from approval_delegation
+	               where organization_id = $1
+	                 and delegator_id = $2
+	                 and delegate_id = $3
+	                 and starts <= $4
+	                 and ends > $4)`
+
+	var delegated bool
+
+	err := r.pool.QueryRow(ctx, query, organizationID, delegatorID, delegateID, at).Scan(&delegated)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	return delegated, nil
+}
+
+func (r *Repository) CreateDelegation(ctx context.Context, delegation model.Delegation) (model.Delegation, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Delegation{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	insert into approval_delegation as d (id, organization_id, delegator_id, delegate_id, starts, ends, creator_id,
+	                                      created)
+	values ($1, $2, $3, $4, $5, $6, $7, $8)
+	returning ` + delegationColumns
+
+	rows, err := tx.Query(ctx, query, delegation.ID, delegation.OrganizationID, delegation.DelegatorID,
+		delegation.DelegateID, delegation.Starts, delegation.Ends, delegation.CreatorID, time.Now())
+	if err != nil {
+		return model.Delegation{}, errors.WithStack(err)
+	}
+
+	d, err := r.collectDelegation(rows)
+	if err != nil {
+		return model.Delegation{}, err
+	}
+
+	err = r.saveDelegationAudit(ctx, tx, delegation.CreatorID, model.AuditActionCreate, d, nil, d)
+	if err != nil {
+		return model.Delegation{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Delegation{}, errors.WithStack(err)
+	}
+
+	return d, nil
+}
+
+// Delegation returns a delegation of the organization, ended or not.
+func (r *Repository) Delegation(ctx context.Context, organizationID, delegationID uuid.UUID) (model.Delegation, error) {
+	query := `
+	select ` + delegationColumns + `
+	from approval_delegation d
+	where d.id = $1
+	  and d.organization_id = $2`
+
+	rows, err := r.pool.Query(ctx, query, delegationID, organizationID)
+	if err != nil {
+		return model.Delegation{}, errors.WithStack(err)
+	}
+
+	return r.collectDelegation(rows)
+}
+
+func (r *Repository) DeleteDelegation(ctx context.Context, organizationID, delegationID, employeeID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	delete
+	from approval_delegation d
+	where d.id = $1
+	  and d.organization_id = $2
+	returning ` + delegationColumns
+
+	rows, err := tx.Query(ctx, query, delegationID, organizationID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	d, err := r.collectDelegation(rows)
+	if err != nil {
+		return err
+	}
+
+	err = r.saveDelegationAudit(ctx, tx, employeeID, model.AuditActionDelete, d, d, nil)
+	if err != nil {
+		return err
+	}
+
+	return errors.WithStack(tx.Commit(ctx))
+}
+
+func (r *Repository) saveDelegationAudit(ctx context.Context, tx pgx.Tx, actorID uuid.UUID, action model.AuditAction,
+	delegation model.Delegation, before, after any) error {
+	event := model.AuditEvent{
+		ActorID:        actorID,
+		Action:         action,
+		EntityType:     model.EntityDelegation,
+		EntityID:       delegation.ID,
+		OrganizationID: delegation.OrganizationID,
+	}
+
+	return r.saveAuditEvent(ctx, tx, event, before, after)
+}
+
+func (r *Repository) collectDelegation(rows pgx.Rows) (model.Delegation, error) {
+	row, err := pgx.CollectExactlyOneRow[delegationRow](rows, pgx.RowToStructByNameLax[delegationRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Delegation{}, errors.WithStack(model.ErrDelegationNotFound)
+		}
+		return model.Delegation{}, errors.WithStack(err)
+	}
+
+	return r.delegationModel(row), nil
+}
+
+func (r *Repository) delegationModel(row delegationRow) model.Delegation {
+	return model.Delegation{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		DelegatorID:    row.DelegatorID,
+		Delegator:      row.Delegator,
+		DelegateID:     row.DelegateID,
+		Delegate:       row.Delegate,
+		Starts:         row.Starts,
+		Ends:           row.Ends,
+		CreatorID:      row.CreatorID,
+		Created:        row.Created,
+	}
+}
+
+type delegationRow struct {
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	DelegatorID    uuid.UUID `db:"delegator_id"`
+	Delegator      string    `db:"delegator"`
+	DelegateID     uuid.UUID `db:"delegate_id"`
+	Delegate       string    `db:"delegate"`
+	Starts         time.Time `db:"starts"`
+	Ends           time.Time `db:"ends"`
+	CreatorID      uuid.UUID `db:"creator_id"`
+	Created        time.Time `db:"created"`
+}
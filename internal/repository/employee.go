@@ -14,11 +14,13 @@ func (r *Repository) Employee(ctx context.Context, username string) (model.Emplo
 	query := `
 	select e.id,
 	       e.username,
-	       array_agg(o.organization_id) organizations
+	       e.platform_admin,
+	       array_agg(o.organization_id) organizations,
+	       array_agg(o.role::text)      roles
 	from employee e
 	         left join organization_employee o on e.id = o.employee_id
 	where username = $1
-	group by e.id, e.username`
+	group by e.id, e.username, e.platform_admin`
 
 	rows, err := r.pool.Query(ctx, query, username)
 	if err != nil {
@@ -37,15 +39,26 @@ func (r *Repository) Employee(ctx context.Context, username string) (model.Emplo
 }
 
 func (r *Repository) employeeModel(row employeeRow) model.Employee {
+	roles := make(map[uuid.UUID]model.Role, len(row.Organizations))
+	for i, organizationID := range row.Organizations {
+		if i < len(row.Roles) {
+			roles[organizationID] = model.Role(row.Roles[i])
+		}
+	}
+
 	return model.Employee{
 		ID:              row.ID,
 		Username:        row.Username,
+		PlatformAdmin:   row.PlatformAdmin,
 		OrganizationIDs: row.Organizations,
+		Roles:           roles,
 	}
 }
 
 type employeeRow struct {
 	ID            uuid.UUID   `db:"id"`
 	Username      string      `db:"username"`
+	PlatformAdmin bool        `db:"platform_admin"`
 	Organizations []uuid.UUID `db:"organizations"`
+	Roles         []string    `db:"roles"`
 }
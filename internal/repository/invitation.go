@@ -0,0 +1,195 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+func (r *Repository) Invitations(ctx context.Context, opts model.InvitationFilter) ([]model.Invitation, error) {
+	b := r.builder.
+		Select("id",
+			"tender_id",
+			"organization_id",
+			"employee_id",
+			"status",
+			"inviter_id",
+			"created",
+			"responded",
+		).From("tender_invitation")
+
+	if opts.InvitationID != uuid.Nil {
+		b = b.Where(sq.Eq{"id": opts.InvitationID})
+	}
+
+	if opts.TenderID != uuid.Nil {
+		b = b.Where(sq.Eq{"tender_id": opts.TenderID})
+	}
+
+	if opts.EmployeeID != uuid.Nil {
+		b = b.Where(sq.Or{
+			sq.Eq{"employee_id": opts.EmployeeID},
+			sq.Eq{"organization_id": opts.OrganizationIDs},
+		})
+	}
+
+	if len(opts.Status) > 0 {
+		b = b.Where(sq.Eq{"status": opts.Status})
+	}
+
+	if opts.Offset > 0 {
+		b = b.Offset(opts.Offset)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	b = b.OrderBy("created").Limit(limit)
+
+	query, args, err := b.ToSql()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	invitationRows, err := pgx.CollectRows[invitationRow](rows, pgx.RowToStructByNameLax[invitationRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	invitations := make([]model.Invitation, 0, len(invitationRows))
+	for _, row := range invitationRows {
+		invitations = append(invitations, r.invitationModel(row))
+	}
+
+	return invitations, nil
+}
+
+func (r *Repository) CreateInvitation(ctx context.Context, invitation model.Invitation) (model.Invitation, error) {
+	query := `
+	insert into tender_invitation (id, tender_id, organization_id, employee_id, status, inviter_id, created)
+	values ($1, $2, $3, $4, $5, $6, $7)
+	returning id, tender_id, organization_id, employee_id, status, inviter_id, created, responded`
+
+	rows, err := r.pool.Query(ctx, query, invitation.ID, invitation.TenderID, nullUUID(invitation.OrganizationID),
+		nullUUID(invitation.EmployeeID), invitation.Status, invitation.InviterID, time.Now())
+	if err != nil {
+		return model.Invitation{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[invitationRow](rows, pgx.RowToStructByNameLax[invitationRow])
+	if err != nil {
+		return model.Invitation{}, errors.WithStack(err)
+	}
+
+	return r.invitationModel(row), nil
+}
+
+func (r *Repository) DeleteInvitation(ctx context.Context, invitationID uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `delete from tender_invitation where id = $1`, invitationID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errors.WithStack(model.ErrInvitationNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repository) RespondInvitation(ctx context.Context, invitationID uuid.UUID, status model.InvitationStatus) (model.Invitation, error) {
+	query := `
+	update tender_invitation set status = $2, responded = $3 where id = $1
+	returning id, tender_id, organization_id, employee_id, status, inviter_id, created, responded`
+
+	rows, err := r.pool.Query(ctx, query, invitationID, status, time.Now())
+	if err != nil {
+		return model.Invitation{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[invitationRow](rows, pgx.RowToStructByNameLax[invitationRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Invitation{}, errors.WithStack(model.ErrInvitationNotFound)
+		}
+		return model.Invitation{}, errors.WithStack(err)
+	}
+
+	return r.invitationModel(row), nil
+}
+
+// checkInvitation admits bids on invite-only tenders only from an accepted invitee, either the bidding
+// organization or its author.
+func (r *Repository) checkInvitation(ctx context.Context, tx pgx.Tx, bid model.Bid) error {
+	query := `
+	select t.visibility = 'Public' or exists (select 1
+	                                          from tender_invitation i
+	                                          where i.tender_id = t.id
+	                                            and i.status = 'Accepted'
+	                                            and (i.organization_id = $2 or i.employee_id = $3))
+	from tender t
+	where t.id = $1`
+
+	var invited bool
+	err := tx.QueryRow(ctx, query, bid.TenderID, bid.OrganizationID, bid.CreatorID).Scan(&invited)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.WithStack(model.ErrTenderOrBidNotFound)
+		}
+		return errors.WithStack(err)
+	}
+
+	if !invited {
+		return errors.WithStack(model.ErrNotInvited)
+	}
+
+	return nil
+}
+
+func (r *Repository) invitationModel(row invitationRow) model.Invitation {
+	i := model.Invitation{
+		ID:        row.ID,
+		TenderID:  row.TenderID,
+		Status:    model.InvitationStatus(row.Status),
+		InviterID: row.InviterID,
+		Created:   row.Created,
+	}
+
+	if row.OrganizationID != nil {
+		i.OrganizationID = *row.OrganizationID
+	}
+
+	if row.EmployeeID != nil {
+		i.EmployeeID = *row.EmployeeID
+	}
+
+	if row.Responded != nil {
+		i.Responded = *row.Responded
+	}
+
+	return i
+}
+
+type invitationRow struct {
+	ID             uuid.UUID  `db:"id"`
+	TenderID       uuid.UUID  `db:"tender_id"`
+	OrganizationID *uuid.UUID `db:"organization_id"`
+	EmployeeID     *uuid.UUID `db:"employee_id"`
+	Status         string     `db:"status"`
+	InviterID      uuid.UUID  `db:"inviter_id"`
+	Created        time.Time  `db:"created"`
+	Responded      *time.Time `db:"responded"`
+}
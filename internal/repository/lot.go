@@ -0,0 +1,503 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const lotColumns = "id, tender_id, name, description, status, awarded_bid_id, created"
+
+func (r *Repository) Lots(ctx context.Context, tenderID uuid.UUID) ([]model.Lot, error) {
+	query := `
+	select ` + lotColumns + `
+	from tender_lot
+	where tender_id = $1
+	  and status <> 'Removed'
+	order by created, id`
+
+	rows, err := r.pool.Query(ctx, query, tenderID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	lotRows, err := pgx.CollectRows[lotRow](rows, pgx.RowToStructByNameLax[lotRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	lots := make([]model.Lot, 0, len(lotRows))
+	for _, row := range lotRows {
+		lots = append(lots, r.lotModel(row))
+	}
+
+	return lots, nil
+}
+
+func (r *Repository) CreateLot(ctx context.Context, lot model.Lot, employeeID uuid.UUID) (model.Lot, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tender, err := r.tenderForUpdate(ctx, tx, lot.TenderID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	if tender.Status == model.TenderStatusClosed {
+		return model.Lot{}, errors.WithStack(model.ErrInvalidLot)
+	}
+
+	query := `
+	insert into tender_lot (id, tender_id, name, description, status, created)
+	values ($1, $2, $3, $4, $5, $6)
+	returning ` + lotColumns
+
+	rows, err := tx.Query(ctx, query, lot.ID, lot.TenderID, lot.Name, lot.Description, model.LotStatusOpen, time.Now())
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[lotRow](rows, pgx.RowToStructByNameLax[lotRow])
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	l := r.lotModel(row)
+
+	_, err = r.bumpTenderVersion(ctx, tx, l.TenderID, employeeID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	err = r.saveLotAudit(ctx, tx, employeeID, model.AuditActionCreate, tender, nil, l)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	return l, nil
+}
+
+func (r *Repository) UpdateLot(ctx context.Context, lot model.Lot, employeeID uuid.UUID) (model.Lot, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tender, err := r.tenderForUpdate(ctx, tx, lot.TenderID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	before, err := r.lotForUpdate(ctx, tx, lot.TenderID, lot.ID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	b := r.builder.Update("tender_lot")
+
+	if lot.Name != "" {
+		b = b.Set("name", lot.Name)
+	}
+
+	if lot.Description != "" {
+		b = b.Set("description", lot.Description)
+	}
+
+	b = b.Where(sq.Eq{"id": lot.ID}).
+		Suffix("returning " + lotColumns)
+
+	query, args, err := b.ToSql()
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[lotRow](rows, pgx.RowToStructByNameLax[lotRow])
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	l := r.lotModel(row)
+
+	_, err = r.bumpTenderVersion(ctx, tx, l.TenderID, employeeID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	err = r.saveLotAudit(ctx, tx, employeeID, model.AuditActionEdit, tender, before, l)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	return l, nil
+}
+
+// SetLotStatus cancels or removes an open lot. Removing is only possible before anyone bid on the lot,
+// and the cancellation of the last open lot closes the tender.
+func (r *Repository) SetLotStatus(ctx context.Context, tenderID, lotID uuid.UUID, status model.LotStatus,
+	employeeID uuid.UUID) (model.Lot, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tender, err := r.tenderForUpdate(ctx, tx, tenderID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	before, err := r.lotForUpdate(ctx, tx, tenderID, lotID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	if status == model.LotStatusRemoved {
+		var hasBids bool
+
+		err = tx.QueryRow(ctx, `select exists (select 1 from bid_lot where lot_id = $1)`, lotID).Scan(&hasBids)
+		if err != nil {
+			return model.Lot{}, errors.WithStack(err)
+		}
+
+		if hasBids {
+			return model.Lot{}, errors.WithStack(model.ErrLotHasBids)
+		}
+	}
+
+	query := `
+	update tender_lot set status = $2 where id = $1
+	returning ` + lotColumns
+
+	rows, err := tx.Query(ctx, query, lotID, status)
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[lotRow](rows, pgx.RowToStructByNameLax[lotRow])
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	l := r.lotModel(row)
+
+	_, err = r.bumpTenderVersion(ctx, tx, tenderID, employeeID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	err = r.saveLotAudit(ctx, tx, employeeID, model.AuditActionStatus, tender, before, l)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	err = r.closeSettledTender(ctx, tx, tenderID, employeeID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	return l, nil
+}
+
+// lotForUpdate locks a lot of the tender that is still open for changes.
+func (r *Repository) lotForUpdate(ctx context.Context, tx pgx.Tx, tenderID, lotID uuid.UUID) (model.Lot, error) {
+	query := `
+	select ` + lotColumns + `
+	from tender_lot
+	where id = $1
+	  and tender_id = $2
+	  and status = 'Open'
+	    for update`
+
+	rows, err := tx.Query(ctx, query, lotID, tenderID)
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[lotRow](rows, pgx.RowToStructByNameLax[lotRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Lot{}, errors.WithStack(model.ErrLotNotFound)
+		}
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	return r.lotModel(row), nil
+}
+
+// awardLot hands an open lot to the bid; the award is part of the tender, so it gets a tender version of its own.
+func (r *Repository) awardLot(ctx context.Context, tx pgx.Tx, tenderID, lotID, bidID, employeeID uuid.UUID) error {
+	tender, err := r.tenderForUpdate(ctx, tx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	before, err := r.lotForUpdate(ctx, tx, tenderID, lotID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	update tender_lot set status = $2, awarded_bid_id = $3 where id = $1
+	returning ` + lotColumns
+
+	rows, err := tx.Query(ctx, query, lotID, model.LotStatusAwarded, bidID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[lotRow](rows, pgx.RowToStructByNameLax[lotRow])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	_, err = r.bumpTenderVersion(ctx, tx, tenderID, employeeID)
+	if err != nil {
+		return err
+	}
+
+	err = r.saveLotAudit(ctx, tx, employeeID, model.AuditActionDecision, tender, before, r.lotModel(row))
+	if err != nil {
+		return err
+	}
+
+	return r.closeSettledTender(ctx, tx, tenderID, employeeID)
+}
+
+// saveBidLots attaches a new bid to the lots it targets. A tender split into lots takes no bid without lots.
+func (r *Repository) saveBidLots(ctx context.Context, tx pgx.Tx, bid model.Bid) error {
+	if len(bid.LotIDs) == 0 {
+		var hasLots bool
+
+		query := `select exists (select 1 from tender_lot where tender_id = $1 and status <> 'Removed')`
+
+		err := tx.QueryRow(ctx, query, bid.TenderID).Scan(&hasLots)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		if hasLots {
+			return errors.WithStack(model.ErrLotRequired)
+		}
+
+		return nil
+	}
+
+	query := `
+	insert into bid_lot (bid_id, lot_id)
+	select $1, id
+	from tender_lot
+	where tender_id = $2
+	  and id = any ($3)
+	  and status = 'Open'`
+
+	tag, err := tx.Exec(ctx, query, bid.ID, bid.TenderID, bid.LotIDs)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if tag.RowsAffected() != int64(len(bid.LotIDs)) {
+		return errors.WithStack(model.ErrLotNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repository) lotSnapshots(ctx context.Context, tx pgx.Tx, tenderID uuid.UUID) ([]model.LotSnapshot, error) {
+	query := `
+	select id, name, description, status::text
+	from tender_lot
+	where tender_id = $1
+	  and status <> 'Removed'
+	order by created, id`
+
+	rows, err := tx.Query(ctx, query, tenderID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	lots, err := pgx.CollectRows[model.LotSnapshot](rows, pgx.RowToStructByPos[model.LotSnapshot])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return lots, nil
+}
+
+// restoreLots brings the lots back to their state in the tender version. Awards stand: an awarded lot keeps
+// its award whatever the version says.
+func (r *Repository) restoreLots(ctx context.Context, tx pgx.Tx, tenderID uuid.UUID, versionID int64) error {
+	query := `
+	with v as (select l.*
+	           from tender_version,
+	                jsonb_to_recordset(coalesce(lots, '[]')) as l(id uuid, name text, description text, status lot_status)
+	           where tender_id = $1
+	             and id = $2)
+	update tender_lot t
+	set name        = coalesce(v.name, t.name),
+	    description = coalesce(v.description, t.description),
+	    status      = coalesce(v.status, 'Removed')
+	from tender_lot c
+	         left join v on v.id = c.id
+	where t.id = c.id
+	  and c.tender_id = $1
+	  and c.status <> 'Awarded'`
+
+	_, err := tx.Exec(ctx, query, tenderID, versionID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) bumpTenderVersion(ctx context.Context, tx pgx.Tx, tenderID, employeeID uuid.UUID) (model.Tender, error) {
+	query := `
+	update tender set version_id = version_id + 1 where id = $1
+	returning ` + tenderColumns
+
+	rows, err := tx.Query(ctx, query, tenderID)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	t := r.tenderModel(row)
+
+	err = r.saveTenderVersion(ctx, tx, t, employeeID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	return t, nil
+}
+
+// closeSettledTender closes a tender split into lots once none of its lots is open any more.
+func (r *Repository) closeSettledTender(ctx context.Context, tx pgx.Tx, tenderID, employeeID uuid.UUID) error {
+	query := `
+	select exists (select 1 from tender_lot where tender_id = $1 and status <> 'Removed')
+	   and not exists (select 1 from tender_lot where tender_id = $1 and status = 'Open')
+	   and status <> 'Closed'
+	from tender
+	where id = $1`
+
+	var settled bool
+
+	err := tx.QueryRow(ctx, query, tenderID).Scan(&settled)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if !settled {
+		return nil
+	}
+
+	return r.closeTender(ctx, tx, tenderID, employeeID)
+}
+
+func (r *Repository) closeTender(ctx context.Context, tx pgx.Tx, tenderID, employeeID uuid.UUID) error {
+	before, err := r.tenderForUpdate(ctx, tx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	query := `update tender set status = $2, version_id = version_id + 1 where id = $1
+	returning ` + tenderColumns
+
+	rows, err := tx.Query(ctx, query, tenderID, model.TenderStatusClosed)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	tender := r.tenderModel(row)
+
+	err = r.saveTenderVersion(ctx, tx, tender, employeeID)
+	if err != nil {
+		return err
+	}
+
+	err = r.saveTenderAudit(ctx, tx, employeeID, model.AuditActionStatus, before, tender)
+	if err != nil {
+		return err
+	}
+
+	return r.saveTenderEvents(ctx, tx, before, tender)
+}
+
+func (r *Repository) saveLotAudit(ctx context.Context, tx pgx.Tx, actorID uuid.UUID, action model.AuditAction,
+	tender model.Tender, before any, after model.Lot) error {
+	event := model.AuditEvent{
+		ActorID:        actorID,
+		Action:         action,
+		EntityType:     model.EntityLot,
+		EntityID:       after.ID,
+		OrganizationID: tender.OrganizationID,
+	}
+
+	return r.saveAuditEvent(ctx, tx, event, before, after)
+}
+
+func (r *Repository) lotModel(row lotRow) model.Lot {
+	l := model.Lot{
+		ID:          row.ID,
+		TenderID:    row.TenderID,
+		Name:        row.Name,
+		Description: row.Description,
+		Status:      model.LotStatus(row.Status),
+		Created:     row.Created,
+	}
+
+	if row.AwardedBidID != nil {
+		l.AwardedBidID = *row.AwardedBidID
+	}
+
+	return l
+}
+
+type lotRow struct {
+	ID           uuid.UUID  `db:"id"`
+	TenderID     uuid.UUID  `db:"tender_id"`
+	Name         string     `db:"name"`
+	Description  string     `db:"description"`
+	Status       string     `db:"status"`
+	AwardedBidID *uuid.UUID `db:"awarded_bid_id"`
+	Created      time.Time  `db:"created"`
+}
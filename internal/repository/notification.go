@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const notificationColumns = `n.id, n.employee_id, n.event_id, n.event_type, n.entity_type, n.entity_id, n.subject, n.body,
+	n.read, n.email_status::text, n.attempts, n.last_error, n.next_attempt, n.created`
+
+func (r *Repository) Notifications(ctx context.Context, opts model.NotificationFilter) ([]model.Notification, error) {
+	b := r.builder.
+		Select(notificationColumns).
+		From("notification n").
+		Where(sq.Eq{"n.employee_id": opts.EmployeeID}).
+		Where("n.in_app")
+
+	if opts.Unread {
+		b = b.Where("n.read is null")
+	}
+
+	if opts.Offset > 0 {
+		b = b.Offset(opts.Offset)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	b = b.OrderBy("n.id desc").Limit(limit)
+
+	query, args, err := b.ToSql()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return r.collectNotifications(rows)
+}
+
+func (r *Repository) MarkNotificationRead(ctx context.Context, employeeID uuid.UUID, notificationID int64) (model.Notification, error) {
+	query := `
+	update notification n
+	set read = coalesce(read, $3)
+	where n.id = $1
+	  and n.employee_id = $2
+	  and n.in_app
+	returning ` + notificationColumns
+
+	rows, err := r.pool.Query(ctx, query, notificationID, employeeID, time.Now())
+	if err != nil {
+		return model.Notification{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[notificationRow](rows, pgx.RowToStructByNameLax[notificationRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Notification{}, errors.WithStack(model.ErrNotificationNotFound)
+		}
+		return model.Notification{}, errors.WithStack(err)
+	}
+
+	return r.notificationModel(row), nil
+}
+
+func (r *Repository) MarkNotificationsRead(ctx context.Context, employeeID uuid.UUID) error {
+	query := `update notification set read = $2 where employee_id = $1 and in_app and read is null`
+
+	_, err := r.pool.Exec(ctx, query, employeeID, time.Now())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// NotificationPreferences returns the stored preferences only, event types without one use the defaults.
+func (r *Repository) NotificationPreferences(ctx context.Context, employeeID uuid.UUID) ([]model.NotificationPreference, error) {
+	query := `
+	select event_type, in_app, email
+	from notification_preference
+	where employee_id = $1
+	order by event_type`
+
+	rows, err := r.pool.Query(ctx, query, employeeID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	preferenceRows, err := pgx.CollectRows[notificationPreferenceRow](rows, pgx.RowToStructByNameLax[notificationPreferenceRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	preferences := make([]model.NotificationPreference, 0, len(preferenceRows))
+	for _, row := range preferenceRows {
+		preferences = append(preferences, model.NotificationPreference{
+			EventType: model.EventType(row.EventType),
+			InApp:     row.InApp,
+			Email:     row.Email,
+		})
+	}
+
+	return preferences, nil
+}
+
+func (r *Repository) SaveNotificationPreferences(ctx context.Context, employeeID uuid.UUID,
+	preferences []model.NotificationPreference) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	for _, p := range preferences {
+		query := `
+		insert into notification_preference (employee_id, event_type, in_app, email)
+		values ($1, $2, $3, $4)
+		on conflict (employee_id, event_type) do update set in_app = excluded.in_app,
+		                                                    email  = excluded.email`
+
+		_, err = tx.Exec(ctx, query, employeeID, p.EventType, p.InApp, p.Email)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return errors.WithStack(tx.Commit(ctx))
+}
+
+// CreateNotifications stores the notification of an event for every recipient who wants it on some channel.
+// Emails are queued only for employees with an address. It is idempotent per employee and event, so a
+// redelivered outbox event does not notify twice.
+func (r *Repository) CreateNotifications(ctx context.Context, event model.Event, recipients model.NotificationRecipients,
+	subject, body string) error {
+	query := `
+	with recipient as (select employee_id id
+	                   from organization_employee
+	                   where organization_id = any ($6)
+	                   union
+	                   select creator_id
+	                   from bid
+	                   where id = $7
+	                      or tender_id = $8)
+	insert
+	into notification (employee_id, event_id, event_type, entity_type, entity_id, subject, body, in_app, email_status,
+	                   next_attempt, created)
+	select e.id,
+	       $1,
+	       $2,
+	       $3,
+	       $4,
+	       $5,
+	       $9,
+	       coalesce(p.in_app, true),
+	       case when coalesce(p.email, true) and e.email is not null then 'Pending'::notification_email_status end,
+	       $10,
+	       $10
+	from recipient r
+	         join employee e on e.id = r.id
+	         left join notification_preference p on p.employee_id = e.id and p.event_type = $2
+	where coalesce(p.in_app, true)
+	   or (coalesce(p.email, true) and e.email is not null)
+	on conflict (employee_id, event_id) do nothing`
+
+	_, err := r.pool.Exec(ctx, query, event.ID, event.Type, event.EntityType, event.EntityID, subject,
+		recipients.OrganizationIDs, nullUUID(recipients.BidID), nullUUID(recipients.TenderID), body, time.Now())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// ClaimNotificationEmails leases a batch of due emails together with the address and name of the recipient.
+func (r *Repository) ClaimNotificationEmails(ctx context.Context, limit uint64, lease time.Duration) ([]model.Notification, error) {
+	query := `
+	update notification n
+	set next_attempt = $2
+	from employee e
+	where n.employee_id = e.id
+	  and n.id in (select id
+	               from notification
+	               where email_status = 'Pending'
+	                 and next_attempt <= $3
+	               order by id
+	               limit $1 for update skip locked)
+	returning ` + notificationColumns + `, e.username, e.email`
+
+	now := time.Now()
+
+	rows, err := r.pool.Query(ctx, query, limit, now.Add(lease), now)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return r.collectNotifications(rows)
+}
+
+func (r *Repository) MarkNotificationSent(ctx context.Context, notificationID int64) error {
+	query := `
+	update notification
+	set email_status = 'Sent',
+	    attempts     = attempts + 1,
+	    last_error   = null,
+	    sent         = $2
+	where id = $1`
+
+	_, err := r.pool.Exec(ctx, query, notificationID, time.Now())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkNotificationFailed(ctx context.Context, notification model.Notification) error {
+	query := `
+	update notification
+	set email_status = $2,
+	    attempts     = attempts + 1,
+	    last_error   = $3,
+	    next_attempt = $4
+	where id = $1`
+
+	_, err := r.pool.Exec(ctx, query,
+		notification.ID, notification.EmailStatus, notification.LastError, notification.NextAttempt,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) collectNotifications(rows pgx.Rows) ([]model.Notification, error) {
+	notificationRows, err := pgx.CollectRows[notificationRow](rows, pgx.RowToStructByNameLax[notificationRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	notifications := make([]model.Notification, 0, len(notificationRows))
+	for _, row := range notificationRows {
+		notifications = append(notifications, r.notificationModel(row))
+	}
+
+	return notifications, nil
+}
+
+func (r *Repository) notificationModel(row notificationRow) model.Notification {
+	n := model.Notification{
+		ID:          row.ID,
+		EmployeeID:  row.EmployeeID,
+		EventID:     row.EventID,
+		EventType:   model.EventType(row.EventType),
+		EntityType:  model.EntityType(row.EntityType),
+		EntityID:    row.EntityID,
+		Subject:     row.Subject,
+		Body:        row.Body,
+		Attempts:    row.Attempts,
+		NextAttempt: row.NextAttempt,
+		Created:     row.Created,
+		Username:    row.Username,
+	}
+
+	if row.Read != nil {
+		n.Read = *row.Read
+	}
+
+	if row.EmailStatus != nil {
+		n.EmailStatus = model.NotificationEmailStatus(*row.EmailStatus)
+	}
+
+	if row.LastError != nil {
+		n.LastError = *row.LastError
+	}
+
+	if row.Email != nil {
+		n.Email = *row.Email
+	}
+
+	return n
+}
+
+type notificationRow struct {
+	ID          int64      `db:"id"`
+	EmployeeID  uuid.UUID  `db:"employee_id"`
+	EventID     int64      `db:"event_id"`
+	EventType   string     `db:"event_type"`
+	EntityType  string     `db:"entity_type"`
+	EntityID    uuid.UUID  `db:"entity_id"`
+	Subject     string     `db:"subject"`
+	Body        string     `db:"body"`
+	Read        *time.Time `db:"read"`
+	EmailStatus *string    `db:"email_status"`
+	Attempts    int        `db:"attempts"`
+	LastError   *string    `db:"last_error"`
+	NextAttempt time.Time  `db:"next_attempt"`
+	Created     time.Time  `db:"created"`
+	Username    string     `db:"username"`
+	Email       *string    `db:"email"`
+}
+
+type notificationPreferenceRow struct {
+	EventType string `db:"event_type"`
+	InApp     bool   `db:"in_app"`
+	Email     bool   `db:"email"`
+}
@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+// ClaimEvents leases a batch of due events so that concurrent relays never pick the same event
+// until the lease expires, which is what makes a crashed relay redeliver instead of losing events.
+func (r *Repository) ClaimEvents(ctx context.Context, limit uint64, lease time.Duration) ([]model.Event, error) {
+	query := `
+	update outbox_event
+	set next_attempt = $2
+	where id in (select id
+	             from outbox_event
+	             where published is null
+	               and next_attempt <= $3
+	             order by id
+	             limit $1 for update skip locked)
+	returning id, type, entity_type, entity_id, organization_ids, payload, attempts, created`
+
+	now := time.Now()
+
+	rows, err := r.pool.Query(ctx, query, limit, now.Add(lease), now)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	eventRows, err := pgx.CollectRows[eventRow](rows, pgx.RowToStructByNameLax[eventRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	events := make([]model.Event, 0, len(eventRows))
+	for _, row := range eventRows {
+		events = append(events, r.eventModel(row))
+	}
+
+	return events, nil
+}
+
+func (r *Repository) MarkEventPublished(ctx context.Context, eventID int64) error {
+	query := `update outbox_event set published = $2, attempts = attempts + 1, last_error = null where id = $1`
+
+	_, err := r.pool.Exec(ctx, query, eventID, time.Now())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkEventFailed(ctx context.Context, eventID int64, nextAttempt time.Time, reason string) error {
+	query := `update outbox_event set next_attempt = $2, attempts = attempts + 1, last_error = $3 where id = $1`
+
+	_, err := r.pool.Exec(ctx, query, eventID, nextAttempt, reason)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) saveTenderEvents(ctx context.Context, tx pgx.Tx, before, after model.Tender) error {
+	if before.Status == after.Status {
+		return nil
+	}
+
+	var eventType model.EventType
+
+	switch after.Status {
+	case model.TenderStatusPublished:
+		eventType = model.EventTenderPublished
+	case model.TenderStatusClosed:
+		eventType = model.EventTenderClosed
+	default:
+		return nil
+	}
+
+	event := model.Event{
+		Type:            eventType,
+		EntityType:      model.EntityTender,
+		EntityID:        after.ID,
+		OrganizationIDs: []uuid.UUID{after.OrganizationID},
+	}
+
+	return r.saveEvent(ctx, tx, event, r.tenderEventPayload(after))
+}
+
+func (r *Repository) tenderEventPayload(tender model.Tender) model.TenderEventPayload {
+	return model.TenderEventPayload{
+		TenderID:       tender.ID,
+		Name:           tender.Name,
+		Status:         tender.Status,
+		OrganizationID: tender.OrganizationID,
+		VersionID:      tender.VersionID,
+	}
+}
+
+func (r *Repository) saveBidEvents(ctx context.Context, tx pgx.Tx, before, after model.Bid) error {
+	if before.Status == after.Status {
+		return nil
+	}
+
+	var eventType model.EventType
+
+	switch after.Status {
+	case model.BidStatusPublished:
+		eventType = model.EventBidSubmitted
+	case model.BidStatusApproved:
+		eventType = model.EventBidApproved
+	case model.BidStatusRejected:
+		eventType = model.EventBidRejected
+	default:
+		return nil
+	}
+
+	payload := model.BidEventPayload{
+		BidID:          after.ID,
+		Name:           after.Name,
+		Status:         after.Status,
+		TenderID:       after.TenderID,
+		OrganizationID: after.OrganizationID,
+		VersionID:      after.VersionID,
+	}
+
+	query := `
+	select organization_id,
+	       bid_mode = 'Sealed' and bids_opened is null and (bids_deadline is null or bids_deadline > $2)
+	from tender
+	where id = $1`
+
+	var sealed bool
+	err := tx.QueryRow(ctx, query, after.TenderID, time.Now()).Scan(&payload.TenderOrganizationID, &sealed)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// The event also reaches the tender organization, which must not learn anything about sealed bids.
+	if sealed {
+		payload.Name = ""
+	}
+
+	event := model.Event{
+		Type:            eventType,
+		EntityType:      model.EntityBid,
+		EntityID:        after.ID,
+		OrganizationIDs: []uuid.UUID{payload.OrganizationID, payload.TenderOrganizationID},
+	}
+
+	return r.saveEvent(ctx, tx, event, payload)
+}
+
+func (r *Repository) saveEvent(ctx context.Context, tx pgx.Tx, event model.Event, payload any) error {
+	var err error
+
+	event.Payload, err = json.Marshal(payload)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	query := `
+	insert into outbox_event (type, entity_type, entity_id, organization_ids, payload, next_attempt, created)
+	values ($1, $2, $3, $4, $5, $6, $6)`
+
+	_, err = tx.Exec(ctx, query,
+		event.Type, event.EntityType, event.EntityID, event.OrganizationIDs, event.Payload, time.Now(),
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) eventModel(row eventRow) model.Event {
+	return model.Event{
+		ID:              row.ID,
+		Type:            model.EventType(row.Type),
+		EntityType:      model.EntityType(row.EntityType),
+		EntityID:        row.EntityID,
+		OrganizationIDs: row.OrganizationIDs,
+		Payload:         row.Payload,
+		Attempts:        row.Attempts,
+		Created:         row.Created,
+	}
+}
+
+type eventRow struct {
+	ID              int64           `db:"id"`
+	Type            string          `db:"type"`
+	EntityType      string          `db:"entity_type"`
+	EntityID        uuid.UUID       `db:"entity_id"`
+	OrganizationIDs []uuid.UUID     `db:"organization_ids"`
+	Payload         json.RawMessage `db:"payload"`
+	Attempts        int             `db:"attempts"`
+	Created         time.Time       `db:"created"`
+}
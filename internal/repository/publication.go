@@ -0,0 +1,209 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+// ScheduleTenderPublication sets or moves the publication time of a created tender; the employee scheduling it
+// is the one it gets published on behalf of.
+func (r *Repository) ScheduleTenderPublication(ctx context.Context, tenderID uuid.UUID, publishAt time.Time,
+	employeeID uuid.UUID) (model.Tender, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := r.tenderForUpdate(ctx, tx, tenderID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	if before.Status != model.TenderStatusCreated {
+		return model.Tender{}, errors.WithStack(model.ErrInvalidPublishAt)
+	}
+
+	t, err := r.setTenderPublication(ctx, tx, tenderID, publishAt, employeeID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = r.saveTenderAudit(ctx, tx, employeeID, model.AuditActionSchedule, before, t)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	return t, nil
+}
+
+func (r *Repository) CancelTenderPublication(ctx context.Context, tenderID, employeeID uuid.UUID) (model.Tender, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := r.tenderForUpdate(ctx, tx, tenderID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	if before.PublishAt.IsZero() {
+		return model.Tender{}, errors.WithStack(model.ErrPublicationNotScheduled)
+	}
+
+	t, err := r.setTenderPublication(ctx, tx, tenderID, time.Time{}, uuid.Nil)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = r.saveTenderAudit(ctx, tx, employeeID, model.AuditActionSchedule, before, t)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	return t, nil
+}
+
+// ClaimScheduledPublications leases a batch of due publications, so that replicas running the job side by side
+// never work on the same tender until the lease expires.
+func (r *Repository) ClaimScheduledPublications(ctx context.Context, limit uint64,
+	lease time.Duration) ([]model.ScheduledPublication, error) {
+	query := `
+	update tender t
+	set publish_lease = $2
+	from employee e
+	where t.publish_by = e.id
+	  and t.id in (select id
+	               from tender
+	               where publish_at <= $3
+	                 and (publish_lease is null or publish_lease <= $3)
+	               order by publish_at
+	               limit $1 for update skip locked)
+	returning t.id, t.name, t.description, t.status, t.category_id,
+	          (select c.name from category c where c.id = t.category_id) category, t.visibility, t.bid_mode,
+	          t.bids_deadline, t.bids_opened, t.publish_at, t.organization_id, t.creator_id, t.version_id, t.created,
+	          e.id employee_id, e.username`
+
+	now := time.Now()
+
+	rows, err := r.pool.Query(ctx, query, limit, now.Add(lease), now)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	publicationRows, err := pgx.CollectRows[publicationRow](rows, pgx.RowToStructByNameLax[publicationRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	publications := make([]model.ScheduledPublication, 0, len(publicationRows))
+	for _, row := range publicationRows {
+		publications = append(publications, model.ScheduledPublication{
+			Tender:     r.tenderModel(row.tenderRow),
+			EmployeeID: row.EmployeeID,
+			Username:   row.Username,
+		})
+	}
+
+	return publications, nil
+}
+
+// PublishScheduledTender publishes the claimed tender through the regular status change, which writes the version,
+// the audit entry and the event. A publication canceled or moved since the claim is left alone, one whose
+// tender is no longer created is dropped.
+func (r *Repository) PublishScheduledTender(ctx context.Context, publication model.ScheduledPublication) (model.Tender, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := r.tenderForUpdate(ctx, tx, publication.Tender.ID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	if !before.PublishAt.Equal(publication.Tender.PublishAt) || before.PublishAt.IsZero() {
+		return model.Tender{}, errors.WithStack(model.ErrPublicationNotScheduled)
+	}
+
+	if before.Status != model.TenderStatusCreated {
+		_, err = r.setTenderPublication(ctx, tx, before.ID, time.Time{}, uuid.Nil)
+		if err != nil {
+			return model.Tender{}, err
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			return model.Tender{}, errors.WithStack(err)
+		}
+
+		return model.Tender{}, errors.WithStack(model.ErrPublicationNotScheduled)
+	}
+
+	change := model.Tender{
+		ID:        before.ID,
+		Status:    model.TenderStatusPublished,
+		CreatorID: publication.EmployeeID,
+	}
+
+	t, err := r.updateTender(ctx, tx, before, change)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	return t, nil
+}
+
+// setTenderPublication schedules the tender, a zero publishAt clears the schedule.
+func (r *Repository) setTenderPublication(ctx context.Context, tx pgx.Tx, tenderID uuid.UUID, publishAt time.Time,
+	employeeID uuid.UUID) (model.Tender, error) {
+	query := `
+	update tender
+	set publish_at    = $2,
+	    publish_by    = $3,
+	    publish_lease = null
+	where id = $1
+	returning ` + tenderColumns
+
+	rows, err := tx.Query(ctx, query, tenderID, nullTime(publishAt), nullUUID(employeeID))
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	return r.tenderModel(row), nil
+}
+
+type publicationRow struct {
+	tenderRow
+	EmployeeID uuid.UUID `db:"employee_id"`
+	Username   string    `db:"username"`
+}
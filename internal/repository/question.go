@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const questionColumns = `id, tender_id, author_id, question, answer, answer_visibility::text, answerer_id,
+	tender_version_id, created, answered`
+
+func (r *Repository) Questions(ctx context.Context, opts model.QuestionFilter) ([]model.Question, error) {
+	b := r.builder.
+		Select(questionColumns).
+		From("tender_question")
+
+	if opts.TenderID != uuid.Nil {
+		b = b.Where(sq.Eq{"tender_id": opts.TenderID})
+	}
+
+	if opts.QuestionID != uuid.Nil {
+		b = b.Where(sq.Eq{"id": opts.QuestionID})
+	}
+
+	if !opts.All {
+		b = b.Where(sq.Or{
+			sq.Eq{"author_id": opts.AuthorID},
+			sq.Eq{"answer_visibility": model.AnswerVisibilityPublic},
+		})
+	}
+
+	if opts.Offset > 0 {
+		b = b.Offset(opts.Offset)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	b = b.OrderBy("created", "id").Limit(limit)
+
+	query, args, err := b.ToSql()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	questionRows, err := pgx.CollectRows[questionRow](rows, pgx.RowToStructByNameLax[questionRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	questions := make([]model.Question, 0, len(questionRows))
+	for _, row := range questionRows {
+		questions = append(questions, r.questionModel(row))
+	}
+
+	return questions, nil
+}
+
+func (r *Repository) CreateQuestion(ctx context.Context, question model.Question, organizationID uuid.UUID) (model.Question, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Question{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	insert into tender_question (id, tender_id, author_id, question, created)
+	values ($1, $2, $3, $4, $5)
+	returning ` + questionColumns
+
+	rows, err := tx.Query(ctx, query, question.ID, question.TenderID, question.AuthorID, question.Question, time.Now())
+	if err != nil {
+		return model.Question{}, errors.WithStack(err)
+	}
+
+	q, err := r.collectQuestion(rows)
+	if err != nil {
+		return model.Question{}, err
+	}
+
+	err = r.saveQuestionAudit(ctx, tx, q.AuthorID, model.AuditActionCreate, organizationID, nil, q)
+	if err != nil {
+		return model.Question{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Question{}, errors.WithStack(err)
+	}
+
+	return q, nil
+}
+
+// AnswerQuestion stores the answer and, when change carries a tender ID, edits the tender in the same
+// transaction so the answer points at the tender version it produced.
+func (r *Repository) AnswerQuestion(ctx context.Context, answer model.Question, change model.Tender) (model.Question, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Question{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	tender, err := r.tenderForUpdate(ctx, tx, answer.TenderID)
+	if err != nil {
+		return model.Question{}, err
+	}
+
+	query := `
+	select ` + questionColumns + `
+	from tender_question
+	where id = $1
+	  and tender_id = $2
+	    for update`
+
+	rows, err := tx.Query(ctx, query, answer.ID, answer.TenderID)
+	if err != nil {
+		return model.Question{}, errors.WithStack(err)
+	}
+
+	before, err := r.collectQuestion(rows)
+	if err != nil {
+		return model.Question{}, err
+	}
+
+	var versionID *int64
+
+	if change.ID != uuid.Nil {
+		change.CreatorID = answer.AnswererID
+
+		t, err := r.updateTender(ctx, tx, tender, change)
+		if err != nil {
+			return model.Question{}, err
+		}
+
+		versionID = &t.VersionID
+	}
+
+	query = `
+	update tender_question
+	set answer            = $2,
+	    answer_visibility = $3,
+	    answerer_id       = $4,
+	    tender_version_id = coalesce($5, tender_version_id),
+	    answered          = $6
+	where id = $1
+	returning ` + questionColumns
+
+	rows, err = tx.Query(ctx, query, answer.ID, answer.Answer, answer.Visibility, answer.AnswererID, versionID,
+		time.Now())
+	if err != nil {
+		return model.Question{}, errors.WithStack(err)
+	}
+
+	q, err := r.collectQuestion(rows)
+	if err != nil {
+		return model.Question{}, err
+	}
+
+	err = r.saveQuestionAudit(ctx, tx, answer.AnswererID, model.AuditActionAnswer, tender.OrganizationID, before, q)
+	if err != nil {
+		return model.Question{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Question{}, errors.WithStack(err)
+	}
+
+	return q, nil
+}
+
+func (r *Repository) saveQuestionAudit(ctx context.Context, tx pgx.Tx, actorID uuid.UUID, action model.AuditAction,
+	organizationID uuid.UUID, before any, after model.Question) error {
+	event := model.AuditEvent{
+		ActorID:        actorID,
+		Action:         action,
+		EntityType:     model.EntityQuestion,
+		EntityID:       after.ID,
+		OrganizationID: organizationID,
+	}
+
+	return r.saveAuditEvent(ctx, tx, event, before, after)
+}
+
+func (r *Repository) collectQuestion(rows pgx.Rows) (model.Question, error) {
+	row, err := pgx.CollectExactlyOneRow[questionRow](rows, pgx.RowToStructByNameLax[questionRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Question{}, errors.WithStack(model.ErrQuestionNotFound)
+		}
+		return model.Question{}, errors.WithStack(err)
+	}
+
+	return r.questionModel(row), nil
+}
+
+func (r *Repository) questionModel(row questionRow) model.Question {
+	q := model.Question{
+		ID:       row.ID,
+		TenderID: row.TenderID,
+		AuthorID: row.AuthorID,
+		Question: row.Question,
+		Created:  row.Created,
+	}
+
+	if row.Answer != nil {
+		q.Answer = *row.Answer
+	}
+
+	if row.AnswerVisibility != nil {
+		q.Visibility = model.AnswerVisibility(*row.AnswerVisibility)
+	}
+
+	if row.AnswererID != nil {
+		q.AnswererID = *row.AnswererID
+	}
+
+	if row.TenderVersionID != nil {
+		q.TenderVersionID = *row.TenderVersionID
+	}
+
+	if row.Answered != nil {
+		q.Answered = *row.Answered
+	}
+
+	return q
+}
+
+type questionRow struct {
+	ID               uuid.UUID  `db:"id"`
+	TenderID         uuid.UUID  `db:"tender_id"`
+	AuthorID         uuid.UUID  `db:"author_id"`
+	Question         string     `db:"question"`
+	Answer           *string    `db:"answer"`
+	AnswerVisibility *string    `db:"answer_visibility"`
+	AnswererID       *uuid.UUID `db:"answerer_id"`
+	TenderVersionID  *int64     `db:"tender_version_id"`
+	Created          time.Time  `db:"created"`
+	Answered         *time.Time `db:"answered"`
+}
@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"time"
+
 	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -18,3 +21,40 @@ func NewRepository(pool *pgxpool.Pool) *Repository {
 		builder: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
 	}
 }
+
+// versionTime is truncated to the precision of a postgres timestamp so that version hashes survive a round trip.
+func versionTime() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func nullUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+
+	return &id
+}
+
+func nullTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+
+	return &t
+}
+
+func nullFloat(f float64) *float64 {
+	if f == 0 {
+		return nil
+	}
+
+	return &f
+}
+
+func nullUUIDs(ids []uuid.UUID) []uuid.UUID {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return ids
+}
@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const reviewColumns = `rv.id, rv.bid_id, rv.author_id, rv.description, rv.rating, rv.created`
+
+const reputationColumns = `subject_type, subject_id, bids, approved, withdrawn, reviews, rating_sum, deliveries, on_time,
+	rating, computed`
+
+// Reviews lists the reviews on bids created by an employee, newest first.
+func (r *Repository) Reviews(ctx context.Context, opts model.ReviewFilter) ([]model.Review, error) {
+	b := r.builder.
+		Select(reviewColumns).
+		From("bid_review rv").
+		Join("bid b on b.id = rv.bid_id").
+		Where(sq.Eq{"b.creator_id": opts.CreatorID})
+
+	if opts.Offset > 0 {
+		b = b.Offset(opts.Offset)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	b = b.OrderBy("rv.created desc", "rv.id").Limit(limit)
+
+	query, args, err := b.ToSql()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	reviewRows, err := pgx.CollectRows[reviewRow](rows, pgx.RowToStructByNameLax[reviewRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	reviews := make([]model.Review, 0, len(reviewRows))
+	for _, row := range reviewRows {
+		reviews = append(reviews, r.reviewModel(row))
+	}
+
+	return reviews, nil
+}
+
+func (r *Repository) CreateReview(ctx context.Context, review model.Review, organizationID uuid.UUID) (model.Review, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Review{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	insert into bid_review as rv (id, bid_id, author_id, description, rating, created)
+	values ($1, $2, $3, $4, $5, $6)
+	returning ` + reviewColumns
+
+	var rating *int
+	if review.Rating > 0 {
+		rating = &review.Rating
+	}
+
+	rows, err := tx.Query(ctx, query, review.ID, review.BidID, review.AuthorID, review.Description, rating, time.Now())
+	if err != nil {
+		return model.Review{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[reviewRow](rows, pgx.RowToStructByNameLax[reviewRow])
+	if err != nil {
+		return model.Review{}, errors.WithStack(err)
+	}
+
+	rv := r.reviewModel(row)
+
+	event := model.AuditEvent{
+		ActorID:        rv.AuthorID,
+		Action:         model.AuditActionCreate,
+		EntityType:     model.EntityReview,
+		EntityID:       rv.ID,
+		OrganizationID: organizationID,
+	}
+
+	err = r.saveAuditEvent(ctx, tx, event, nil, rv)
+	if err != nil {
+		return model.Review{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Review{}, errors.WithStack(err)
+	}
+
+	return rv, nil
+}
+
+// ConfirmDelivery records whether an approved bid was delivered on time. A later confirmation corrects
+// the earlier one.
+func (r *Repository) ConfirmDelivery(ctx context.Context, delivery model.Delivery, organizationID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	bid, err := r.bidForUpdate(ctx, tx, delivery.BidID)
+	if err != nil {
+		return err
+	}
+
+	if bid.Status != model.BidStatusApproved {
+		return errors.WithStack(model.ErrInvalidDelivery)
+	}
+
+	query := `
+	insert into bid_delivery (bid_id, on_time, employee_id, created)
+	values ($1, $2, $3, $4)
+	on conflict (bid_id) do update set on_time     = excluded.on_time,
+	                                   employee_id = excluded.employee_id,
+	                                   created     = excluded.created`
+
+	_, err = tx.Exec(ctx, query, delivery.BidID, delivery.OnTime, delivery.EmployeeID, time.Now())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	event := model.AuditEvent{
+		ActorID:        delivery.EmployeeID,
+		Action:         model.AuditActionDeliver,
+		EntityType:     model.EntityBid,
+		EntityID:       delivery.BidID,
+		OrganizationID: organizationID,
+	}
+
+	err = r.saveAuditEvent(ctx, tx, event, nil, delivery)
+	if err != nil {
+		return err
+	}
+
+	return errors.WithStack(tx.Commit(ctx))
+}
+
+// OrganizationReputation returns the last computed reputation of an organization. An organization that
+// has not been rated yet gets an empty one.
+func (r *Repository) OrganizationReputation(ctx context.Context, organizationID uuid.UUID) (model.Reputation, error) {
+	query := `
+	select 'Organization' subject_type, o.id subject_id, coalesce(rep.bids, 0) bids,
+	       coalesce(rep.approved, 0) approved, coalesce(rep.withdrawn, 0) withdrawn, coalesce(rep.reviews, 0) reviews,
+	       coalesce(rep.rating_sum, 0) rating_sum, coalesce(rep.deliveries, 0) deliveries,
+	       coalesce(rep.on_time, 0) on_time, rep.rating, rep.computed
+	from organization o
+	         left join supplier_reputation rep on rep.subject_type = 'Organization' and rep.subject_id = o.id
+	where o.id = $1`
+
+	rows, err := r.pool.Query(ctx, query, organizationID)
+	if err != nil {
+		return model.Reputation{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[reputationRow](rows, pgx.RowToStructByNameLax[reputationRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Reputation{}, errors.WithStack(model.ErrOrganizationNotFound)
+		}
+		return model.Reputation{}, errors.WithStack(err)
+	}
+
+	return r.reputationModel(row), nil
+}
+
+// ReputationInputs counts, for every bid author, what their reputation is computed from.
+func (r *Repository) ReputationInputs(ctx context.Context) ([]model.Reputation, error) {
+	query := `
+	with subject as (select b.id, b.tender_id, b.status, b.creator_type subject_type,
+	                        ` + bidReputationSubject + ` subject_id
+	                 from bid b),
+	     bids as (select s.subject_type, s.subject_id,
+	                     count(*)                                      bids,
+	                     count(*) filter (where s.status = 'Approved') approved,
+	                     count(*) filter (where s.status = 'Canceled') withdrawn
+	              from subject s
+	                       join tender t on t.id = s.tender_id
+	              where t.status = 'Closed'
+	                and exists (select 1 from bid_version v where v.bid_id = s.id and v.status <> 'Created')
+	              group by s.subject_type, s.subject_id),
+	     reviews as (select s.subject_type, s.subject_id, count(rv.rating) reviews, coalesce(sum(rv.rating), 0) rating_sum
+	                 from subject s
+	                          join bid_review rv on rv.bid_id = s.id
+	                 group by s.subject_type, s.subject_id),
+	     deliveries as (select s.subject_type, s.subject_id, count(*) deliveries, count(*) filter (where d.on_time) on_time
+	                    from subject s
+	                             join bid_delivery d on d.bid_id = s.id
+	                    group by s.subject_type, s.subject_id)
+	select s.subject_type, s.subject_id, coalesce(b.bids, 0) bids, coalesce(b.approved, 0) approved,
+	       coalesce(b.withdrawn, 0) withdrawn, coalesce(rv.reviews, 0) reviews, coalesce(rv.rating_sum, 0) rating_sum,
+	       coalesce(d.deliveries, 0) deliveries, coalesce(d.on_time, 0) on_time
+	from (select distinct subject_type, subject_id from subject) s
+	         left join bids b on b.subject_type = s.subject_type and b.subject_id = s.subject_id
+	         left join reviews rv on rv.subject_type = s.subject_type and rv.subject_id = s.subject_id
+	         left join deliveries d on d.subject_type = s.subject_type and d.subject_id = s.subject_id`
+
+	rows, err := r.pool.Query(ctx, query)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	reputationRows, err := pgx.CollectRows[reputationRow](rows, pgx.RowToStructByNameLax[reputationRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	reputations := make([]model.Reputation, 0, len(reputationRows))
+	for _, row := range reputationRows {
+		reputations = append(reputations, r.reputationModel(row))
+	}
+
+	return reputations, nil
+}
+
+// SaveReputations replaces the stored reputations with freshly computed ones.
+func (r *Repository) SaveReputations(ctx context.Context, reputations []model.Reputation) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	insert into supplier_reputation (` + reputationColumns + `)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	on conflict (subject_type, subject_id) do update set bids       = excluded.bids,
+	                                                     approved   = excluded.approved,
+	                                                     withdrawn  = excluded.withdrawn,
+	                                                     reviews    = excluded.reviews,
+	                                                     rating_sum = excluded.rating_sum,
+	                                                     deliveries = excluded.deliveries,
+	                                                     on_time    = excluded.on_time,
+	                                                     rating     = excluded.rating,
+	                                                     computed   = excluded.computed`
+
+	batch := &pgx.Batch{}
+	for _, rep := range reputations {
+		var rating *float64
+		if rep.Rated {
+			rating = &rep.Rating
+		}
+
+		batch.Queue(query, rep.SubjectType, rep.SubjectID, rep.Bids, rep.Approved, rep.Withdrawn, rep.Reviews,
+			rep.RatingSum, rep.Deliveries, rep.OnTime, rating, rep.Computed)
+	}
+
+	err = tx.SendBatch(ctx, batch).Close()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(tx.Commit(ctx))
+}
+
+func (r *Repository) reviewModel(row reviewRow) model.Review {
+	rv := model.Review{
+		ID:          row.ID,
+		BidID:       row.BidID,
+		AuthorID:    row.AuthorID,
+		Description: row.Description,
+		Created:     row.Created,
+	}
+
+	if row.Rating != nil {
+		rv.Rating = int(*row.Rating)
+	}
+
+	return rv
+}
+
+func (r *Repository) reputationModel(row reputationRow) model.Reputation {
+	rep := model.Reputation{
+		SubjectType: model.CreatorType(row.SubjectType),
+		SubjectID:   row.SubjectID,
+		Bids:        int(row.Bids),
+		Approved:    int(row.Approved),
+		Withdrawn:   int(row.Withdrawn),
+		Reviews:     int(row.Reviews),
+		RatingSum:   int(row.RatingSum),
+		Deliveries:  int(row.Deliveries),
+		OnTime:      int(row.OnTime),
+	}
+
+	if row.Rating != nil {
+		rep.Rating = *row.Rating
+		rep.Rated = true
+	}
+
+	if row.Computed != nil {
+		rep.Computed = *row.Computed
+	}
+
+	return rep
+}
+
+type reviewRow struct {
+	ID          uuid.UUID `db:"id"`
+	BidID       uuid.UUID `db:"bid_id"`
+	AuthorID    uuid.UUID `db:"author_id"`
+	Description string    `db:"description"`
+	Rating      *int16    `db:"rating"`
+	Created     time.Time `db:"created"`
+}
+
+type reputationRow struct {
+	SubjectType string     `db:"subject_type"`
+	SubjectID   uuid.UUID  `db:"subject_id"`
+	Bids        int64      `db:"bids"`
+	Approved    int64      `db:"approved"`
+	Withdrawn   int64      `db:"withdrawn"`
+	Reviews     int64      `db:"reviews"`
+	RatingSum   int64      `db:"rating_sum"`
+	Deliveries  int64      `db:"deliveries"`
+	OnTime      int64      `db:"on_time"`
+	Rating      *float64   `db:"rating"`
+	Computed    *time.Time `db:"computed"`
+}
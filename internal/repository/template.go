@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const templateColumns = `id, organization_id, name, tender_name, description, category_id,
+	(select c.name from category c where c.id = category_id) category, visibility, bid_mode, creator_id, created`
+
+func (r *Repository) TenderTemplates(ctx context.Context, organizationID uuid.UUID) ([]model.TenderTemplate, error) {
+	query := `
+	select ` + templateColumns + `
+	from tender_template
+	where organization_id = $1
+	order by name, id`
+
+	rows, err := r.pool.Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	templateRows, err := pgx.CollectRows[templateRow](rows, pgx.RowToStructByNameLax[templateRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	templates := make([]model.TenderTemplate, 0, len(templateRows))
+	for _, row := range templateRows {
+		templates = append(templates, r.templateModel(row))
+	}
+
+	return templates, nil
+}
+
+func (r *Repository) TenderTemplate(ctx context.Context, templateID uuid.UUID) (model.TenderTemplate, error) {
+	query := `
+	select ` + templateColumns + `
+	from tender_template
+	where id = $1`
+
+	rows, err := r.pool.Query(ctx, query, templateID)
+	if err != nil {
+		return model.TenderTemplate{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[templateRow](rows, pgx.RowToStructByNameLax[templateRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.TenderTemplate{}, errors.WithStack(model.ErrTemplateNotFound)
+		}
+		return model.TenderTemplate{}, errors.WithStack(err)
+	}
+
+	return r.templateModel(row), nil
+}
+
+func (r *Repository) CreateTenderTemplate(ctx context.Context, template model.TenderTemplate) (model.TenderTemplate, error) {
+	query := `
+	insert into tender_template (id, organization_id, name, tender_name, description, category_id, visibility,
+	                             bid_mode, creator_id, created)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	returning ` + templateColumns
+
+	rows, err := r.pool.Query(ctx, query, template.ID, template.OrganizationID, template.Name, template.TenderName,
+		template.Description, template.CategoryID, template.Visibility, template.BidMode, template.CreatorID,
+		time.Now())
+	if err != nil {
+		return model.TenderTemplate{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[templateRow](rows, pgx.RowToStructByNameLax[templateRow])
+	if err != nil {
+		return model.TenderTemplate{}, errors.WithStack(err)
+	}
+
+	return r.templateModel(row), nil
+}
+
+func (r *Repository) DeleteTenderTemplate(ctx context.Context, templateID uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `delete from tender_template where id = $1`, templateID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errors.WithStack(model.ErrTemplateNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repository) templateModel(row templateRow) model.TenderTemplate {
+	return model.TenderTemplate{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		Name:           row.Name,
+		TenderName:     row.TenderName,
+		Description:    row.Description,
+		CategoryID:     row.CategoryID,
+		Category:       row.Category,
+		Visibility:     model.TenderVisibility(row.Visibility),
+		BidMode:        model.TenderBidMode(row.BidMode),
+		CreatorID:      row.CreatorID,
+		Created:        row.Created,
+	}
+}
+
+type templateRow struct {
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	Name           string    `db:"name"`
+	TenderName     string    `db:"tender_name"`
+	Description    string    `db:"description"`
+	CategoryID     uuid.UUID `db:"category_id"`
+	Category       string    `db:"category"`
+	Visibility     string    `db:"visibility"`
+	BidMode        string    `db:"bid_mode"`
+	CreatorID      uuid.UUID `db:"creator_id"`
+	Created        time.Time `db:"created"`
+}
@@ -12,20 +12,37 @@ import (
 	"zadanie-6105/internal/model"
 )
 
+const tenderColumns = "id, name, description, status, category_id, " +
+	"(select c.name from category c where c.id = category_id) category, visibility, bid_mode, bids_deadline, " +
+	"bids_opened, publish_at, organization_id, creator_id, version_id, created"
+
+// categorySubtree selects the category passed as its argument and every category below it.
+const categorySubtree = `with recursive subtree as (select id
+	                                   from category
+	                                   where id = ?
+	                                   union all
+	                                   select c.id
+	                                   from category c
+	                                            join subtree s on c.parent_id = s.id)
+	select id
+	from subtree`
+
 func (r *Repository) Tenders(ctx context.Context, opts model.TenderFilter) ([]model.Tender, error) {
 	b := r.builder.
-		Select("id",
-			"name",
-			"description",
-			"status",
-			"service_type",
-			"organization_id",
-			"creator_id",
-			"version_id",
-			"created",
-		).From("tender").Where(sq.Or{
+		Select(tenderColumns).From("tender").Where(sq.Or{
 		sq.Eq{"organization_id": opts.OrganizationIDs},
-		sq.Eq{"status": model.TenderStatusPublished},
+		sq.And{
+			sq.Eq{"status": model.TenderStatusPublished},
+			sq.Or{
+				sq.Eq{"visibility": model.TenderVisibilityPublic},
+				sq.Expr(`exists (select 1
+				                 from tender_invitation i
+				                 where i.tender_id = tender.id
+				                   and i.status <> 'Declined'
+				                   and (i.employee_id = ? or i.organization_id = any (?)))`,
+					opts.EmployeeID, opts.OrganizationIDs),
+			},
+		},
 	})
 
 	if opts.TenderID != uuid.Nil {
@@ -36,8 +53,8 @@ func (r *Repository) Tenders(ctx context.Context, opts model.TenderFilter) ([]mo
 		b = b.Where(sq.Eq{"creator_id": opts.CreatorID})
 	}
 
-	if opts.ServiceType != "" {
-		b = b.Where(sq.Eq{"service_type": opts.ServiceType})
+	if opts.CategoryID != uuid.Nil {
+		b = b.Where("category_id in ("+categorySubtree+")", opts.CategoryID)
 	}
 
 	if len(opts.Status) > 0 {
@@ -89,25 +106,40 @@ func (r *Repository) CreateTender(ctx context.Context, tender model.Tender) (mod
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
-	query := `
-	insert into tender (id, name, description, status, service_type, organization_id, creator_id, version_id, created)
-	values ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-	returning id, name, description, status, service_type, organization_id, creator_id, version_id, created`
+	t, err := r.createTender(ctx, tx, tender, nil)
+	if err != nil {
+		return model.Tender{}, err
+	}
 
-	rows, err := tx.Query(ctx, query, tender.ID, tender.Name, tender.Description, tender.Status, tender.ServiceType,
-		tender.OrganizationID, tender.CreatorID, 1, time.Now())
+	err = r.saveTenderAudit(ctx, tx, tender.CreatorID, model.AuditActionCreate, nil, t)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = tx.Commit(ctx)
 	if err != nil {
 		return model.Tender{}, errors.WithStack(err)
 	}
 
-	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
+	return t, nil
+}
+
+// CloneTender creates the tender together with its lots. The audit entry keeps the source version as its before
+// state, so the origin of a clone stays traceable.
+func (r *Repository) CloneTender(ctx context.Context, tender model.Tender, lots []model.Lot,
+	source model.TenderVersion) (model.Tender, error) {
+	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return model.Tender{}, errors.WithStack(err)
 	}
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	t := r.tenderModel(row)
+	t, err := r.createTender(ctx, tx, tender, lots)
+	if err != nil {
+		return model.Tender{}, err
+	}
 
-	err = r.saveTenderVersion(ctx, tx, t)
+	err = r.saveTenderAudit(ctx, tx, tender.CreatorID, model.AuditActionClone, source, t)
 	if err != nil {
 		return model.Tender{}, err
 	}
@@ -120,6 +152,50 @@ func (r *Repository) CreateTender(ctx context.Context, tender model.Tender) (mod
 	return t, nil
 }
 
+// createTender inserts the tender and its lots and records the first version, which already lists the lots.
+func (r *Repository) createTender(ctx context.Context, tx pgx.Tx, tender model.Tender, lots []model.Lot) (model.Tender, error) {
+	query := `
+	insert into tender (id, name, description, status, category_id, visibility, bid_mode, bids_deadline,
+	                    publish_at, publish_by, organization_id, creator_id, version_id, created)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, case when $9::timestamp is not null then $10::uuid end, $11, $10, $12,
+	        $13)
+	returning ` + tenderColumns
+
+	now := time.Now()
+
+	rows, err := tx.Query(ctx, query, tender.ID, tender.Name, tender.Description, tender.Status, tender.CategoryID,
+		tender.Visibility, tender.BidMode, nullTime(tender.BidsDeadline), nullTime(tender.PublishAt), tender.CreatorID,
+		tender.OrganizationID, 1, now)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	t := r.tenderModel(row)
+
+	for _, l := range lots {
+		query = `
+		insert into tender_lot (id, tender_id, name, description, status, created)
+		values ($1, $2, $3, $4, $5, $6)`
+
+		_, err = tx.Exec(ctx, query, l.ID, t.ID, l.Name, l.Description, model.LotStatusOpen, now)
+		if err != nil {
+			return model.Tender{}, errors.WithStack(err)
+		}
+	}
+
+	err = r.saveTenderVersion(ctx, tx, t, tender.CreatorID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	return t, nil
+}
+
 func (r *Repository) UpdateTender(ctx context.Context, tender model.Tender) (model.Tender, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
@@ -127,16 +203,28 @@ func (r *Repository) UpdateTender(ctx context.Context, tender model.Tender) (mod
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
+	before, err := r.tenderForUpdate(ctx, tx, tender.ID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	t, err := r.updateTender(ctx, tx, before, tender)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	return t, nil
+}
+
+// updateTender applies the non-empty fields of tender to the locked before state as a new version,
+// tender.CreatorID being the employee making the change.
+func (r *Repository) updateTender(ctx context.Context, tx pgx.Tx, before, tender model.Tender) (model.Tender, error) {
 	b := r.builder.Update("tender").
-		PrefixExpr(
-			sq.StatementBuilder.
-				Select("id").
-				From("tender").
-				Where(sq.And{
-					sq.Eq{"id": tender.ID},
-					sq.Eq{"creator_id": tender.CreatorID},
-				}).Prefix("with t as (").Suffix("for update)"),
-		).
 		Set("version_id", sq.Expr("version_id + 1"))
 
 	if tender.Name != "" {
@@ -147,18 +235,24 @@ func (r *Repository) UpdateTender(ctx context.Context, tender model.Tender) (mod
 		b = b.Set("description", tender.Description)
 	}
 
+	// Any status change settles a scheduled publication.
 	if tender.Status != "" {
-		b = b.Set("status", tender.Status)
+		b = b.Set("status", tender.Status).
+			Set("publish_at", nil).
+			Set("publish_by", nil).
+			Set("publish_lease", nil)
+	}
+
+	if tender.CategoryID != uuid.Nil {
+		b = b.Set("category_id", tender.CategoryID)
 	}
 
-	if tender.ServiceType != "" {
-		b = b.Set("service_type", tender.ServiceType)
+	if tender.Visibility != "" {
+		b = b.Set("visibility", tender.Visibility)
 	}
 
-	b = b.Where(sq.And{
-		sq.Eq{"id": tender.ID},
-		sq.Eq{"creator_id": tender.CreatorID},
-	}).Suffix("returning id, name, description, status, service_type, organization_id, creator_id, version_id, created")
+	b = b.Where(sq.Eq{"id": tender.ID}).
+		Suffix("returning " + tenderColumns)
 
 	query, args, err := b.ToSql()
 	if err != nil {
@@ -172,37 +266,48 @@ func (r *Repository) UpdateTender(ctx context.Context, tender model.Tender) (mod
 
 	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return model.Tender{}, errors.WithStack(model.ErrCreatorNotFound)
-		}
 		return model.Tender{}, errors.WithStack(err)
 	}
 
 	t := r.tenderModel(row)
 
-	err = r.saveTenderVersion(ctx, tx, t)
+	err = r.saveTenderVersion(ctx, tx, t, tender.CreatorID)
 	if err != nil {
 		return model.Tender{}, err
 	}
 
-	err = tx.Commit(ctx)
+	action := model.AuditActionEdit
+	if tender.Status != "" {
+		action = model.AuditActionStatus
+	}
+
+	err = r.saveTenderAudit(ctx, tx, tender.CreatorID, action, before, t)
 	if err != nil {
-		return model.Tender{}, errors.WithStack(err)
+		return model.Tender{}, err
+	}
+
+	err = r.saveTenderEvents(ctx, tx, before, t)
+	if err != nil {
+		return model.Tender{}, err
 	}
 
 	return t, nil
 }
 
-func (r *Repository) RollbackTender(ctx context.Context, tenderID uuid.UUID, versionID int64, creatorID uuid.UUID) (model.Tender, error) {
+func (r *Repository) RollbackTender(ctx context.Context, tenderID uuid.UUID, versionID int64, employeeID uuid.UUID) (model.Tender, error) {
 	tx, err := r.pool.Begin(ctx)
 	if err != nil {
 		return model.Tender{}, errors.WithStack(err)
 	}
 	defer func() { _ = tx.Rollback(ctx) }()
 
+	before, err := r.tenderForUpdate(ctx, tx, tenderID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
 	query := `
-	with u as (select id from tender where id = $1 and creator_id = $3 for update),
-	     v as (select name, description, status, service_type
+	with v as (select name, description, status, category_id
 	           from tender_version
 	           where tender_id = $1
 	             and id = $2)
@@ -210,13 +315,15 @@ func (r *Repository) RollbackTender(ctx context.Context, tenderID uuid.UUID, ver
 	set name         = v.name,
 	    description  = v.description,
 	    status       = v.status,
-	    service_type = v.service_type,
+	    category_id  = coalesce(v.category_id, t.category_id),
 	    version_id   = version_id + 1
 	from v
-	where id = $1 and creator_id = $3
-	returning t.id, t.name, t.description, t.status, t.service_type, t.organization_id, t.creator_id, t.version_id, t.created`
+	where id = $1
+	returning t.id, t.name, t.description, t.status, t.category_id,
+	          (select c.name from category c where c.id = t.category_id) category, t.visibility, t.bid_mode,
+	          t.bids_deadline, t.bids_opened, t.publish_at, t.organization_id, t.creator_id, t.version_id, t.created`
 
-	rows, err := tx.Query(ctx, query, tenderID, versionID, creatorID)
+	rows, err := tx.Query(ctx, query, tenderID, versionID)
 	if err != nil {
 		return model.Tender{}, errors.WithStack(err)
 	}
@@ -224,14 +331,75 @@ func (r *Repository) RollbackTender(ctx context.Context, tenderID uuid.UUID, ver
 	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return model.Tender{}, errors.WithStack(model.ErrCreatorNotFound)
+			return model.Tender{}, errors.WithStack(model.ErrTenderOrVersionNotFound)
 		}
 		return model.Tender{}, errors.WithStack(err)
 	}
 
 	t := r.tenderModel(row)
 
-	err = r.saveTenderVersion(ctx, tx, t)
+	err = r.restoreLots(ctx, tx, tenderID, versionID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = r.restoreAttachments(ctx, tx, model.EntityTender, tenderID, versionID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = r.saveTenderVersion(ctx, tx, t, employeeID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = r.saveTenderAudit(ctx, tx, employeeID, model.AuditActionRollback, before, t)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = r.saveTenderEvents(ctx, tx, before, t)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	return t, nil
+}
+
+func (r *Repository) TransferTender(ctx context.Context, tenderID, ownerID, employeeID uuid.UUID) (model.Tender, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := r.tenderForUpdate(ctx, tx, tenderID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	query := `
+	update tender set creator_id = $2 where id = $1
+	returning ` + tenderColumns
+
+	rows, err := tx.Query(ctx, query, tenderID, ownerID)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	t := r.tenderModel(row)
+
+	err = r.saveTenderAudit(ctx, tx, employeeID, model.AuditActionTransfer, before, t)
 	if err != nil {
 		return model.Tender{}, err
 	}
@@ -244,43 +412,278 @@ func (r *Repository) RollbackTender(ctx context.Context, tenderID uuid.UUID, ver
 	return t, nil
 }
 
-func (r *Repository) saveTenderVersion(ctx context.Context, tx pgx.Tx, tender model.Tender) error {
+// OpenTenderBids reveals the sealed bids of a tender at once; an opening that already happened is not repeated.
+func (r *Repository) OpenTenderBids(ctx context.Context, tenderID, employeeID uuid.UUID) (model.Tender, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	before, err := r.tenderForUpdate(ctx, tx, tenderID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	if before.BidMode != model.TenderBidModeSealed || !before.BidsOpened.IsZero() {
+		return model.Tender{}, errors.WithStack(model.ErrBidsNotSealed)
+	}
+
 	query := `
-	insert into tender_version (id, tender_id, name, description, status, service_type, created) 
-	values ($1, $2, $3, $4, $5, $6, $7)`
+	update tender set bids_opened = $2 where id = $1
+	returning ` + tenderColumns
 
-	_, err := tx.Exec(ctx, query,
-		tender.VersionID, tender.ID, tender.Name, tender.Description, tender.Status, tender.ServiceType, time.Now(),
+	rows, err := tx.Query(ctx, query, tenderID, time.Now())
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	t := r.tenderModel(row)
+
+	err = r.saveTenderAudit(ctx, tx, employeeID, model.AuditActionOpenBids, before, t)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	event := model.Event{
+		Type:            model.EventTenderBidsOpened,
+		EntityType:      model.EntityTender,
+		EntityID:        t.ID,
+		OrganizationIDs: []uuid.UUID{t.OrganizationID},
+	}
+
+	err = r.saveEvent(ctx, tx, event, r.tenderEventPayload(t))
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	return t, nil
+}
+
+func (r *Repository) tenderForUpdate(ctx context.Context, tx pgx.Tx, tenderID uuid.UUID) (model.Tender, error) {
+	query := `
+	select ` + tenderColumns + `
+	from tender where id = $1 for update`
+
+	rows, err := tx.Query(ctx, query, tenderID)
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[tenderRow](rows, pgx.RowToStructByNameLax[tenderRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Tender{}, errors.WithStack(model.ErrTenderOrVersionNotFound)
+		}
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	return r.tenderModel(row), nil
+}
+
+func (r *Repository) saveTenderAudit(ctx context.Context, tx pgx.Tx, actorID uuid.UUID, action model.AuditAction,
+	before any, after model.Tender) error {
+	event := model.AuditEvent{
+		ActorID:        actorID,
+		Action:         action,
+		EntityType:     model.EntityTender,
+		EntityID:       after.ID,
+		OrganizationID: after.OrganizationID,
+	}
+
+	return r.saveAuditEvent(ctx, tx, event, before, after)
+}
+
+func (r *Repository) TenderVersions(ctx context.Context, tenderID uuid.UUID) ([]model.TenderVersion, error) {
+	query := `
+	select id, tender_id, name, description, status, category_id, category, employee_id, lots, attachments, created,
+	       hash_scheme, hash, prev_hash
+	from tender_version
+	where tender_id = $1
+	order by id`
+
+	rows, err := r.pool.Query(ctx, query, tenderID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	versionRows, err := pgx.CollectRows[tenderVersionRow](rows, pgx.RowToStructByNameLax[tenderVersionRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	versions := make([]model.TenderVersion, 0, len(versionRows))
+	for _, row := range versionRows {
+		versions = append(versions, r.tenderVersionModel(row))
+	}
+
+	return versions, nil
+}
+
+func (r *Repository) saveTenderVersion(ctx context.Context, tx pgx.Tx, tender model.Tender, employeeID uuid.UUID) error {
+	v := model.TenderVersion{
+		ID:          tender.VersionID,
+		TenderID:    tender.ID,
+		Name:        tender.Name,
+		Description: tender.Description,
+		Status:      tender.Status,
+		CategoryID:  tender.CategoryID,
+		Category:    tender.Category,
+		EmployeeID:  employeeID,
+		Created:     versionTime(),
+		HashScheme:  model.TenderHashScheme,
+	}
+
+	var err error
+
+	v.Lots, err = r.lotSnapshots(ctx, tx, tender.ID)
+	if err != nil {
+		return err
+	}
+
+	v.Attachments, err = r.attachmentSet(ctx, tx, model.EntityTender, tender.ID)
+	if err != nil {
+		return err
+	}
+
+	query := `select hash from tender_version where tender_id = $1 order by id desc limit 1`
+
+	err = tx.QueryRow(ctx, query, tender.ID).Scan(&v.PrevHash)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return errors.WithStack(err)
+	}
+
+	v.Hash = v.ComputeHash()
+
+	var lots any
+	if len(v.Lots) > 0 {
+		lots = v.Lots
+	}
+
+	query = `
+	insert into tender_version (id, tender_id, name, description, status, category_id, category, employee_id, lots,
+	                            attachments, created, hash_scheme, hash, prev_hash)
+	values ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`
+
+	_, err = tx.Exec(ctx, query,
+		v.ID, v.TenderID, v.Name, v.Description, v.Status, v.CategoryID, v.Category, v.EmployeeID, lots,
+		nullUUIDs(v.Attachments), v.Created, v.HashScheme, v.Hash, v.PrevHash,
 	)
 	if err != nil {
 		return errors.WithStack(err)
 	}
 
-	return nil
+	return r.notifyChange(ctx, tx, model.Change{
+		EntityType: model.EntityTender,
+		EntityID:   tender.ID,
+		TenderID:   tender.ID,
+		VersionID:  v.ID,
+		Status:     string(v.Status),
+		Created:    v.Created,
+	})
 }
 
 func (r *Repository) tenderModel(row tenderRow) model.Tender {
-	return model.Tender{
+	t := model.Tender{
 		ID:             row.ID,
 		Name:           row.Name,
 		Description:    row.Description,
-		ServiceType:    model.ServiceType(row.ServiceType),
+		CategoryID:     row.CategoryID,
+		Category:       row.Category,
 		Status:         model.TenderStatus(row.Status),
+		Visibility:     model.TenderVisibility(row.Visibility),
+		BidMode:        model.TenderBidMode(row.BidMode),
 		OrganizationID: row.OrganizationID,
 		CreatorID:      row.CreatorID,
 		VersionID:      row.VersionID,
 		Created:        row.Created,
 	}
+
+	if row.BidsDeadline != nil {
+		t.BidsDeadline = *row.BidsDeadline
+	}
+
+	if row.BidsOpened != nil {
+		t.BidsOpened = *row.BidsOpened
+	}
+
+	if row.PublishAt != nil {
+		t.PublishAt = *row.PublishAt
+	}
+
+	return t
 }
 
 type tenderRow struct {
-	ID             uuid.UUID `db:"id"`
-	Name           string    `db:"name"`
-	Description    string    `db:"description"`
-	Status         string    `db:"status"`
-	ServiceType    string    `db:"service_type"`
-	OrganizationID uuid.UUID `db:"organization_id"`
-	CreatorID      uuid.UUID `db:"creator_id"`
-	VersionID      int64     `db:"version_id"`
-	Created        time.Time `db:"created"`
+	ID             uuid.UUID  `db:"id"`
+	Name           string     `db:"name"`
+	Description    string     `db:"description"`
+	Status         string     `db:"status"`
+	CategoryID     uuid.UUID  `db:"category_id"`
+	Category       string     `db:"category"`
+	Visibility     string     `db:"visibility"`
+	BidMode        string     `db:"bid_mode"`
+	BidsDeadline   *time.Time `db:"bids_deadline"`
+	BidsOpened     *time.Time `db:"bids_opened"`
+	PublishAt      *time.Time `db:"publish_at"`
+	OrganizationID uuid.UUID  `db:"organization_id"`
+	CreatorID      uuid.UUID  `db:"creator_id"`
+	VersionID      int64      `db:"version_id"`
+	Created        time.Time  `db:"created"`
+}
+
+func (r *Repository) tenderVersionModel(row tenderVersionRow) model.TenderVersion {
+	var employeeID uuid.UUID
+	if row.EmployeeID != nil {
+		employeeID = *row.EmployeeID
+	}
+
+	var categoryID uuid.UUID
+	if row.CategoryID != nil {
+		categoryID = *row.CategoryID
+	}
+
+	return model.TenderVersion{
+		ID:          row.ID,
+		TenderID:    row.TenderID,
+		Name:        row.Name,
+		Description: row.Description,
+		Status:      model.TenderStatus(row.Status),
+		CategoryID:  categoryID,
+		Category:    row.Category,
+		EmployeeID:  employeeID,
+		Lots:        row.Lots,
+		Attachments: row.Attachments,
+		Created:     row.Created,
+		HashScheme:  row.HashScheme,
+		Hash:        row.Hash,
+		PrevHash:    row.PrevHash,
+	}
+}
+
+type tenderVersionRow struct {
+	ID          int64               `db:"id"`
+	TenderID    uuid.UUID           `db:"tender_id"`
+	Name        string              `db:"name"`
+	Description string              `db:"description"`
+	Status      string              `db:"status"`
+	CategoryID  *uuid.UUID          `db:"category_id"`
+	Category    string              `db:"category"`
+	EmployeeID  *uuid.UUID          `db:"employee_id"`
+	Lots        []model.LotSnapshot `db:"lots"`
+	Attachments []uuid.UUID         `db:"attachments"`
+	Created     time.Time           `db:"created"`
+	HashScheme  int                 `db:"hash_scheme"`
+	Hash        string              `db:"hash"`
+	PrevHash    string              `db:"prev_hash"`
 }
@@ -0,0 +1,353 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+func (r *Repository) Webhooks(ctx context.Context, organizationID uuid.UUID) ([]model.Webhook, error) {
+	query := `
+	select id, organization_id, url, event_types, secret, creator_id, created
+	from webhook
+	where organization_id = $1
+	order by created`
+
+	rows, err := r.pool.Query(ctx, query, organizationID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	webhookRows, err := pgx.CollectRows[webhookRow](rows, pgx.RowToStructByNameLax[webhookRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	webhooks := make([]model.Webhook, 0, len(webhookRows))
+	for _, row := range webhookRows {
+		webhooks = append(webhooks, r.webhookModel(row))
+	}
+
+	return webhooks, nil
+}
+
+func (r *Repository) Webhook(ctx context.Context, webhookID uuid.UUID) (model.Webhook, error) {
+	query := `
+	select id, organization_id, url, event_types, secret, creator_id, created
+	from webhook
+	where id = $1`
+
+	rows, err := r.pool.Query(ctx, query, webhookID)
+	if err != nil {
+		return model.Webhook{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[webhookRow](rows, pgx.RowToStructByNameLax[webhookRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.Webhook{}, errors.WithStack(model.ErrWebhookNotFound)
+		}
+		return model.Webhook{}, errors.WithStack(err)
+	}
+
+	return r.webhookModel(row), nil
+}
+
+func (r *Repository) CreateWebhook(ctx context.Context, webhook model.Webhook) (model.Webhook, error) {
+	query := `
+	insert into webhook (id, organization_id, url, event_types, secret, creator_id, created)
+	values ($1, $2, $3, $4, $5, $6, $7)
+	returning id, organization_id, url, event_types, secret, creator_id, created`
+
+	eventTypes := make([]string, 0, len(webhook.EventTypes))
+	for _, t := range webhook.EventTypes {
+		eventTypes = append(eventTypes, string(t))
+	}
+
+	rows, err := r.pool.Query(ctx, query, webhook.ID, webhook.OrganizationID, webhook.URL, eventTypes,
+		webhook.Secret, webhook.CreatorID, time.Now())
+	if err != nil {
+		return model.Webhook{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[webhookRow](rows, pgx.RowToStructByNameLax[webhookRow])
+	if err != nil {
+		return model.Webhook{}, errors.WithStack(err)
+	}
+
+	return r.webhookModel(row), nil
+}
+
+func (r *Repository) DeleteWebhook(ctx context.Context, webhookID uuid.UUID) error {
+	tag, err := r.pool.Exec(ctx, `delete from webhook where id = $1`, webhookID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return errors.WithStack(model.ErrWebhookNotFound)
+	}
+
+	return nil
+}
+
+func (r *Repository) WebhookDeliveries(ctx context.Context, opts model.WebhookDeliveryFilter) ([]model.WebhookDelivery, error) {
+	b := r.builder.
+		Select("d.id",
+			"d.webhook_id",
+			"d.event_id",
+			"d.event_type",
+			"d.payload",
+			"d.status",
+			"d.attempts",
+			"d.response_code",
+			"d.last_error",
+			"d.next_attempt",
+			"d.delivered",
+			"d.created",
+			"w.url",
+		).From("webhook_delivery d").Join("webhook w on d.webhook_id = w.id").
+		Where(sq.Eq{"d.webhook_id": opts.WebhookID})
+
+	if len(opts.Status) > 0 {
+		b = b.Where(sq.Eq{"d.status": opts.Status})
+	}
+
+	if opts.Offset > 0 {
+		b = b.Offset(opts.Offset)
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+
+	b = b.OrderBy("d.id desc").Limit(limit)
+
+	query, args, err := b.ToSql()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	deliveryRows, err := pgx.CollectRows[webhookDeliveryRow](rows, pgx.RowToStructByNameLax[webhookDeliveryRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	deliveries := make([]model.WebhookDelivery, 0, len(deliveryRows))
+	for _, row := range deliveryRows {
+		deliveries = append(deliveries, r.webhookDeliveryModel(row))
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) RedeliverWebhook(ctx context.Context, webhookID uuid.UUID, deliveryID int64) (model.WebhookDelivery, error) {
+	query := `
+	update webhook_delivery d
+	set status       = 'Pending',
+	    next_attempt = $3
+	from webhook w
+	where d.webhook_id = w.id
+	  and d.id = $1
+	  and d.webhook_id = $2
+	returning d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.response_code,
+	          d.last_error, d.next_attempt, d.delivered, d.created, w.url`
+
+	rows, err := r.pool.Query(ctx, query, deliveryID, webhookID, time.Now())
+	if err != nil {
+		return model.WebhookDelivery{}, errors.WithStack(err)
+	}
+
+	row, err := pgx.CollectExactlyOneRow[webhookDeliveryRow](rows, pgx.RowToStructByNameLax[webhookDeliveryRow])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.WebhookDelivery{}, errors.WithStack(model.ErrWebhookNotFound)
+		}
+		return model.WebhookDelivery{}, errors.WithStack(err)
+	}
+
+	return r.webhookDeliveryModel(row), nil
+}
+
+// CreateWebhookDeliveries is idempotent per webhook and event, so a redelivered outbox event does not fan out twice.
+func (r *Repository) CreateWebhookDeliveries(ctx context.Context, event model.Event, payload json.RawMessage) error {
+	query := `
+	insert into webhook_delivery (webhook_id, event_id, event_type, payload, status, next_attempt, created)
+	select id, $1, $2, $3, 'Pending', $4, $4
+	from webhook
+	where organization_id = any ($5)
+	  and (cardinality(event_types) = 0 or $2 = any (event_types))
+	on conflict (webhook_id, event_id) do nothing`
+
+	_, err := r.pool.Exec(ctx, query, event.ID, event.Type, payload, time.Now(), event.OrganizationIDs)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) ClaimWebhookDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]model.WebhookDelivery, error) {
+	query := `
+	update webhook_delivery d
+	set next_attempt = $2
+	from webhook w
+	where d.webhook_id = w.id
+	  and d.id in (select id
+	               from webhook_delivery
+	               where status = 'Pending'
+	                 and next_attempt <= $3
+	               order by id
+	               limit $1 for update skip locked)
+	returning d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.status, d.attempts, d.response_code,
+	          d.last_error, d.next_attempt, d.delivered, d.created, w.url, w.secret`
+
+	now := time.Now()
+
+	rows, err := r.pool.Query(ctx, query, limit, now.Add(lease), now)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	deliveryRows, err := pgx.CollectRows[webhookDeliveryRow](rows, pgx.RowToStructByNameLax[webhookDeliveryRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	deliveries := make([]model.WebhookDelivery, 0, len(deliveryRows))
+	for _, row := range deliveryRows {
+		deliveries = append(deliveries, r.webhookDeliveryModel(row))
+	}
+
+	return deliveries, nil
+}
+
+func (r *Repository) MarkWebhookDelivered(ctx context.Context, deliveryID int64, responseCode int) error {
+	query := `
+	update webhook_delivery
+	set status        = 'Delivered',
+	    attempts      = attempts + 1,
+	    response_code = $2,
+	    last_error    = null,
+	    delivered     = $3
+	where id = $1`
+
+	_, err := r.pool.Exec(ctx, query, deliveryID, responseCode, time.Now())
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) MarkWebhookFailed(ctx context.Context, delivery model.WebhookDelivery) error {
+	var responseCode *int
+	if delivery.ResponseCode != 0 {
+		responseCode = &delivery.ResponseCode
+	}
+
+	query := `
+	update webhook_delivery
+	set status        = $2,
+	    attempts      = attempts + 1,
+	    response_code = $3,
+	    last_error    = $4,
+	    next_attempt  = $5
+	where id = $1`
+
+	_, err := r.pool.Exec(ctx, query,
+		delivery.ID, delivery.Status, responseCode, delivery.LastError, delivery.NextAttempt,
+	)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+func (r *Repository) webhookModel(row webhookRow) model.Webhook {
+	eventTypes := make([]model.EventType, 0, len(row.EventTypes))
+	for _, t := range row.EventTypes {
+		eventTypes = append(eventTypes, model.EventType(t))
+	}
+
+	return model.Webhook{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		URL:            row.URL,
+		EventTypes:     eventTypes,
+		Secret:         row.Secret,
+		CreatorID:      row.CreatorID,
+		Created:        row.Created,
+	}
+}
+
+func (r *Repository) webhookDeliveryModel(row webhookDeliveryRow) model.WebhookDelivery {
+	d := model.WebhookDelivery{
+		ID:          row.ID,
+		WebhookID:   row.WebhookID,
+		EventID:     row.EventID,
+		EventType:   model.EventType(row.EventType),
+		Payload:     row.Payload,
+		Status:      model.WebhookDeliveryStatus(row.Status),
+		Attempts:    row.Attempts,
+		NextAttempt: row.NextAttempt,
+		Created:     row.Created,
+		URL:         row.URL,
+		Secret:      row.Secret,
+	}
+
+	if row.ResponseCode != nil {
+		d.ResponseCode = *row.ResponseCode
+	}
+
+	if row.LastError != nil {
+		d.LastError = *row.LastError
+	}
+
+	if row.Delivered != nil {
+		d.Delivered = *row.Delivered
+	}
+
+	return d
+}
+
+type webhookRow struct {
+	ID             uuid.UUID `db:"id"`
+	OrganizationID uuid.UUID `db:"organization_id"`
+	URL            string    `db:"url"`
+	EventTypes     []string  `db:"event_types"`
+	Secret         string    `db:"secret"`
+	CreatorID      uuid.UUID `db:"creator_id"`
+	Created        time.Time `db:"created"`
+}
+
+type webhookDeliveryRow struct {
+	ID           int64           `db:"id"`
+	WebhookID    uuid.UUID       `db:"webhook_id"`
+	EventID      int64           `db:"event_id"`
+	EventType    string          `db:"event_type"`
+	Payload      json.RawMessage `db:"payload"`
+	Status       string          `db:"status"`
+	Attempts     int             `db:"attempts"`
+	ResponseCode *int            `db:"response_code"`
+	LastError    *string         `db:"last_error"`
+	NextAttempt  time.Time       `db:"next_attempt"`
+	Delivered    *time.Time      `db:"delivered"`
+	Created      time.Time       `db:"created"`
+	URL          string          `db:"url"`
+	Secret       string          `db:"secret"`
+}
@@ -0,0 +1,464 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"zadanie-6105/internal/model"
+)
+
+const workflowColumns = `w.id, w.organization_id, w.category_id, w.name, w.creator_id, w.updated`
+
+type queryer interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func (r *Repository) Workflows(ctx context.Context, organizationID uuid.UUID) ([]model.Workflow, error) {
+	query := `
+	select ` + workflowColumns + `
+	from approval_workflow w
+	where w.organization_id = $1
+	order by w.category_id nulls first, w.id`
+
+	return r.workflows(ctx, r.pool, query, organizationID)
+}
+
+// TenderWorkflow finds the workflow of the closest category of the tender, falling back to the workflow
+// of the organization without category.
+func (r *Repository) TenderWorkflow(ctx context.Context, organizationID, categoryID uuid.UUID) (model.Workflow, error) {
+	query := `
+	with recursive ancestor as (select id, parent_id, 0 depth
+	                            from category
+	                            where id = $2
+	                            union all
+	                            select c.id, c.parent_id, a.depth + 1
+	                            from category c
+	                                     join ancestor a on c.id = a.parent_id)
+	select ` + workflowColumns + `
+	from approval_workflow w
+	         left join ancestor a on a.id = w.category_id
+	where w.organization_id = $1
+	  and (w.category_id is null or a.id is not null)
+	order by a.depth nulls last
+	limit 1`
+
+	workflows, err := r.workflows(ctx, r.pool, query, organizationID, categoryID)
+	if err != nil {
+		return model.Workflow{}, err
+	}
+
+	if len(workflows) == 0 {
+		return model.Workflow{}, errors.WithStack(model.ErrWorkflowNotFound)
+	}
+
+	return workflows[0], nil
+}
+
+// SaveWorkflow creates the workflow of the organization for the category, or replaces its stages. Bids
+// already in approval keep the stages they started with.
+func (r *Repository) SaveWorkflow(ctx context.Context, workflow model.Workflow) (model.Workflow, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return model.Workflow{}, errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	select ` + workflowColumns + `
+	from approval_workflow w
+	where w.organization_id = $1
+	  and w.category_id is not distinct from $2
+	    for update`
+
+	existing, err := r.workflows(ctx, tx, query, workflow.OrganizationID, nullUUID(workflow.CategoryID))
+	if err != nil {
+		return model.Workflow{}, err
+	}
+
+	var before any
+
+	action := model.AuditActionCreate
+	if len(existing) > 0 {
+		before = existing[0]
+		action = model.AuditActionEdit
+		workflow.ID = existing[0].ID
+	}
+
+	query = `
+	insert into approval_workflow (id, organization_id, category_id, name, creator_id, updated)
+	values ($1, $2, $3, $4, $5, $6)
+	on conflict (id) do update set name       = excluded.name,
+	                               creator_id = excluded.creator_id,
+	                               updated    = excluded.updated`
+
+	_, err = tx.Exec(ctx, query, workflow.ID, workflow.OrganizationID, nullUUID(workflow.CategoryID), workflow.Name,
+		workflow.CreatorID, time.Now())
+	if err != nil {
+		return model.Workflow{}, errors.WithStack(err)
+	}
+
+	_, err = tx.Exec(ctx, `delete from approval_stage where workflow_id = $1`, workflow.ID)
+	if err != nil {
+		return model.Workflow{}, errors.WithStack(err)
+	}
+
+	query = `
+	insert into approval_stage (workflow_id, position, name, quorum, approver_ids)
+	values ($1, $2, $3, $4, $5)`
+
+	batch := &pgx.Batch{}
+	for i, stage := range workflow.Stages {
+		batch.Queue(query, workflow.ID, i+1, stage.Name, stage.Quorum, stage.ApproverIDs)
+	}
+
+	err = tx.SendBatch(ctx, batch).Close()
+	if err != nil {
+		return model.Workflow{}, errors.WithStack(err)
+	}
+
+	query = `
+	select ` + workflowColumns + `
+	from approval_workflow w
+	where w.id = $1`
+
+	saved, err := r.workflows(ctx, tx, query, workflow.ID)
+	if err != nil {
+		return model.Workflow{}, err
+	}
+
+	w := saved[0]
+
+	err = r.saveWorkflowAudit(ctx, tx, workflow.CreatorID, action, w, before, w)
+	if err != nil {
+		return model.Workflow{}, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return model.Workflow{}, errors.WithStack(err)
+	}
+
+	return w, nil
+}
+
+func (r *Repository) DeleteWorkflow(ctx context.Context, organizationID, workflowID, employeeID uuid.UUID) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	query := `
+	select ` + workflowColumns + `
+	from approval_workflow w
+	where w.id = $1
+	  and w.organization_id = $2
+	    for update`
+
+	workflows, err := r.workflows(ctx, tx, query, workflowID, organizationID)
+	if err != nil {
+		return err
+	}
+
+	if len(workflows) == 0 {
+		return errors.WithStack(model.ErrWorkflowNotFound)
+	}
+
+	_, err = tx.Exec(ctx, `delete from approval_workflow where id = $1`, workflowID)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	err = r.saveWorkflowAudit(ctx, tx, employeeID, model.AuditActionDelete, workflows[0], workflows[0], nil)
+	if err != nil {
+		return err
+	}
+
+	return errors.WithStack(tx.Commit(ctx))
+}
+
+// StageResults lists the settled approval stages of a bid and its lots in the order they settled.
+func (r *Repository) StageResults(ctx context.Context, bidID uuid.UUID) ([]model.StageResult, error) {
+	query := `
+	select bid_id, lot_id, stage, name, status, created
+	from bid_stage
+	where bid_id = $1
+	order by created, stage`
+
+	rows, err := r.pool.Query(ctx, query, bidID)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	stageRows, err := pgx.CollectRows[stageResultRow](rows, pgx.RowToStructByNameLax[stageResultRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	results := make([]model.StageResult, 0, len(stageRows))
+	for _, row := range stageRows {
+		results = append(results, r.stageResultModel(row))
+	}
+
+	return results, nil
+}
+
+// pinWorkflow returns the workflow the bid is decided under, fixing the given one at the first vote on
+// the bid. A bid with votes and no workflow fixed keeps the single vote.
+func (r *Repository) pinWorkflow(ctx context.Context, tx pgx.Tx, decision model.BidDecision) (model.Workflow, error) {
+	query := `select workflow_id, stages from bid_workflow where bid_id = $1`
+
+	rows, err := tx.Query(ctx, query, decision.BidID)
+	if err != nil {
+		return model.Workflow{}, errors.WithStack(err)
+	}
+
+	pinned, err := pgx.CollectRows[bidWorkflowRow](rows, pgx.RowToStructByNameLax[bidWorkflowRow])
+	if err != nil {
+		return model.Workflow{}, errors.WithStack(err)
+	}
+
+	if len(pinned) > 0 {
+		w := model.Workflow{Stages: pinned[0].Stages}
+		if pinned[0].WorkflowID != nil {
+			w.ID = *pinned[0].WorkflowID
+		}
+
+		return w, nil
+	}
+
+	if len(decision.Workflow.Stages) == 0 {
+		return model.Workflow{}, nil
+	}
+
+	var voted bool
+
+	err = tx.QueryRow(ctx, `select exists (select 1 from bid_agreement where bid_id = $1)`, decision.BidID).Scan(&voted)
+	if err != nil {
+		return model.Workflow{}, errors.WithStack(err)
+	}
+
+	if voted {
+		return model.Workflow{}, nil
+	}
+
+	query = `
+	insert into bid_workflow (bid_id, workflow_id, stages, created)
+	values ($1, $2, $3, $4)`
+
+	_, err = tx.Exec(ctx, query, decision.BidID, decision.Workflow.ID, decision.Workflow.Stages, time.Now())
+	if err != nil {
+		return model.Workflow{}, errors.WithStack(err)
+	}
+
+	return decision.Workflow, nil
+}
+
+// currentStage is the index of the first stage of the workflow the bid, or its lot, has not passed yet.
+// A rejected stage ends the approval for good.
+func (r *Repository) currentStage(ctx context.Context, tx pgx.Tx, decision model.BidDecision) (int, error) {
+	query := `
+	select count(*) filter (where status = 'Approved'), count(*) filter (where status = 'Rejected') > 0
+	from bid_stage
+	where bid_id = $1
+	  and lot_id is not distinct from $2`
+
+	var (
+		passed   int
+		rejected bool
+	)
+
+	err := tx.QueryRow(ctx, query, decision.BidID, nullUUID(decision.LotID)).Scan(&passed, &rejected)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	if rejected {
+		return 0, errors.WithStack(model.ErrStageRejected)
+	}
+
+	if passed >= len(decision.Workflow.Stages) {
+		return 0, errors.WithStack(model.ErrStagesPassed)
+	}
+
+	return passed, nil
+}
+
+// stageOutcome settles the current stage once its quorum approves or any of its approvers rejects. Passing
+// a stage other than the last one leaves the bid undecided.
+func (r *Repository) stageOutcome(ctx context.Context, tx pgx.Tx, decision model.BidDecision,
+	index int) (model.BidStatus, error) {
+	stage := decision.Workflow.Stages[index]
+
+	query := `
+	select case
+	           when count(*) filter (where status = 'Rejected') > 0 then 'Rejected'
+	           when count(*) filter (where status = 'Approved') >= $4 then 'Approved'
+	           else ''
+	           end
+	from bid_agreement
+	where bid_id = $1
+	  and lot_id is not distinct from $2
+	  and stage = $3`
+
+	var outcome model.BidStatus
+
+	err := tx.QueryRow(ctx, query, decision.BidID, nullUUID(decision.LotID), index+1, stage.Quorum).Scan(&outcome)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	if outcome == "" {
+		return "", nil
+	}
+
+	query = `
+	insert into bid_stage (bid_id, lot_id, stage, name, status, created)
+	values ($1, $2, $3, $4, $5, $6)`
+
+	_, err = tx.Exec(ctx, query, decision.BidID, nullUUID(decision.LotID), index+1, stage.Name, outcome, time.Now())
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	if outcome == model.BidStatusApproved && index < len(decision.Workflow.Stages)-1 {
+		return "", nil
+	}
+
+	return outcome, nil
+}
+
+func (r *Repository) workflows(ctx context.Context, q queryer, query string, args ...any) ([]model.Workflow, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	workflowRows, err := pgx.CollectRows[workflowRow](rows, pgx.RowToStructByNameLax[workflowRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if len(workflowRows) == 0 {
+		return nil, nil
+	}
+
+	workflows := make([]model.Workflow, 0, len(workflowRows))
+	index := make(map[uuid.UUID]int, len(workflowRows))
+	ids := make([]uuid.UUID, 0, len(workflowRows))
+
+	for i, row := range workflowRows {
+		index[row.ID] = i
+		ids = append(ids, row.ID)
+		workflows = append(workflows, r.workflowModel(row))
+	}
+
+	query = `
+	select s.workflow_id, s.name, s.quorum, s.approver_ids,
+	       array(select e.username from employee e where e.id = any (s.approver_ids) order by e.username) approvers
+	from approval_stage s
+	where s.workflow_id = any ($1)
+	order by s.workflow_id, s.position`
+
+	rows, err = q.Query(ctx, query, ids)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	stageRows, err := pgx.CollectRows[stageRow](rows, pgx.RowToStructByNameLax[stageRow])
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, row := range stageRows {
+		i := index[row.WorkflowID]
+		workflows[i].Stages = append(workflows[i].Stages, model.Stage{
+			Name:        row.Name,
+			Quorum:      int(row.Quorum),
+			ApproverIDs: row.ApproverIDs,
+			Approvers:   row.Approvers,
+		})
+	}
+
+	return workflows, nil
+}
+
+func (r *Repository) saveWorkflowAudit(ctx context.Context, tx pgx.Tx, actorID uuid.UUID, action model.AuditAction,
+	workflow model.Workflow, before, after any) error {
+	event := model.AuditEvent{
+		ActorID:        actorID,
+		Action:         action,
+		EntityType:     model.EntityWorkflow,
+		EntityID:       workflow.ID,
+		OrganizationID: workflow.OrganizationID,
+	}
+
+	return r.saveAuditEvent(ctx, tx, event, before, after)
+}
+
+func (r *Repository) workflowModel(row workflowRow) model.Workflow {
+	w := model.Workflow{
+		ID:             row.ID,
+		OrganizationID: row.OrganizationID,
+		Name:           row.Name,
+		CreatorID:      row.CreatorID,
+		Updated:        row.Updated,
+	}
+
+	if row.CategoryID != nil {
+		w.CategoryID = *row.CategoryID
+	}
+
+	return w
+}
+
+func (r *Repository) stageResultModel(row stageResultRow) model.StageResult {
+	s := model.StageResult{
+		BidID:   row.BidID,
+		Stage:   int(row.Stage),
+		Name:    row.Name,
+		Status:  model.BidStatus(row.Status),
+		Created: row.Created,
+	}
+
+	if row.LotID != nil {
+		s.LotID = *row.LotID
+	}
+
+	return s
+}
+
+type workflowRow struct {
+	ID             uuid.UUID  `db:"id"`
+	OrganizationID uuid.UUID  `db:"organization_id"`
+	CategoryID     *uuid.UUID `db:"category_id"`
+	Name           string     `db:"name"`
+	CreatorID      uuid.UUID  `db:"creator_id"`
+	Updated        time.Time  `db:"updated"`
+}
+
+type stageRow struct {
+	WorkflowID  uuid.UUID   `db:"workflow_id"`
+	Name        string      `db:"name"`
+	Quorum      int16       `db:"quorum"`
+	ApproverIDs []uuid.UUID `db:"approver_ids"`
+	Approvers   []string    `db:"approvers"`
+}
+
+type bidWorkflowRow struct {
+	WorkflowID *uuid.UUID    `db:"workflow_id"`
+	Stages     []model.Stage `db:"stages"`
+}
+
+type stageResultRow struct {
+	BidID   uuid.UUID  `db:"bid_id"`
+	LotID   *uuid.UUID `db:"lot_id"`
+	Stage   int16      `db:"stage"`
+	Name    string     `db:"name"`
+	Status  string     `db:"status"`
+	Created time.Time  `db:"created"`
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"zadanie-6105/internal/model"
+)
+
+// sniffLen is how much of an upload http.DetectContentType looks at.
+const sniffLen = 512
+
+func (s *Service) Attachments(ctx context.Context, username string, entityType model.EntityType,
+	entityID uuid.UUID) ([]model.Attachment, error) {
+	err := s.checkAttachmentViewer(ctx, username, entityType, entityID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.Attachments(ctx, entityType, entityID)
+}
+
+// Attachment returns the metadata and the content of an attachment, the caller closes the content.
+func (s *Service) Attachment(ctx context.Context, username string, entityType model.EntityType,
+	entityID, attachmentID uuid.UUID) (model.Attachment, io.ReadCloser, error) {
+	err := s.checkAttachmentViewer(ctx, username, entityType, entityID)
+	if err != nil {
+		return model.Attachment{}, nil, err
+	}
+
+	a, err := s.repository.Attachment(ctx, entityType, entityID, attachmentID)
+	if err != nil {
+		return model.Attachment{}, nil, err
+	}
+
+	content, err := s.storage.Get(ctx, a.StorageKey)
+	if err != nil {
+		return model.Attachment{}, nil, err
+	}
+
+	return a, content, nil
+}
+
+// UploadAttachment stores the content and records it. The type is sniffed from the content rather than trusted
+// from the client, which is only asked when the content does not tell.
+func (s *Service) UploadAttachment(ctx context.Context, username string, attachment model.Attachment,
+	content io.Reader) (model.Attachment, error) {
+	if attachment.Name == "" {
+		return model.Attachment{}, model.ErrInvalidAttachment
+	}
+
+	if attachment.Size > model.MaxAttachmentSize {
+		return model.Attachment{}, model.ErrAttachmentTooLarge
+	}
+
+	employee, err := s.attachmentEditor(ctx, username, attachment.EntityType, attachment.EntityID)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+
+	r := bufio.NewReaderSize(content, sniffLen)
+
+	head, err := r.Peek(sniffLen)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return model.Attachment{}, errors.WithStack(err)
+	}
+
+	attachment.ContentType = attachmentType(head, attachment.ContentType)
+	if !model.AttachmentTypeAllowed(attachment.ContentType) {
+		return model.Attachment{}, model.ErrAttachmentType
+	}
+
+	attachment.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Attachment{}, errors.WithStack(err)
+	}
+
+	attachment.StorageKey = fmt.Sprintf("%s/%s/%s", strings.ToLower(string(attachment.EntityType)),
+		attachment.EntityID, attachment.ID)
+	attachment.UploaderID = employee.ID
+
+	size := attachment.Size
+	if size <= 0 {
+		size = -1
+	}
+
+	hash := sha256.New()
+	counter := &countingReader{r: io.TeeReader(io.LimitReader(r, model.MaxAttachmentSize+1), hash)}
+
+	err = s.storage.Put(ctx, attachment.StorageKey, counter, size, attachment.ContentType)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+
+	if counter.n > model.MaxAttachmentSize {
+		s.discardAttachment(ctx, attachment.StorageKey)
+		return model.Attachment{}, model.ErrAttachmentTooLarge
+	}
+
+	attachment.Size = counter.n
+	attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	a, err := s.repository.CreateAttachment(ctx, attachment)
+	if err != nil {
+		s.discardAttachment(ctx, attachment.StorageKey)
+		return model.Attachment{}, err
+	}
+
+	return a, nil
+}
+
+// RemoveAttachment only takes the file out of the current set, the content stays for the versions listing it.
+func (s *Service) RemoveAttachment(ctx context.Context, username string, entityType model.EntityType,
+	entityID, attachmentID uuid.UUID) (model.Attachment, error) {
+	employee, err := s.attachmentEditor(ctx, username, entityType, entityID)
+	if err != nil {
+		return model.Attachment{}, err
+	}
+
+	return s.repository.RemoveAttachment(ctx, entityType, entityID, attachmentID, employee.ID)
+}
+
+// checkAttachmentViewer lets through whoever can see the tender or the bid; files of a sealed bid stay hidden
+// like its description.
+func (s *Service) checkAttachmentViewer(ctx context.Context, username string, entityType model.EntityType,
+	entityID uuid.UUID) error {
+	switch entityType {
+	case model.EntityTender:
+		_, err := s.Tender(ctx, username, model.TenderFilter{TenderID: entityID})
+		return err
+	case model.EntityBid:
+		bid, err := s.Bid(ctx, username, entityID)
+		if err != nil {
+			return err
+		}
+
+		if bid.Sealed {
+			return model.ErrBidsSealed
+		}
+
+		return nil
+	default:
+		return model.ErrAttachmentNotFound
+	}
+}
+
+func (s *Service) attachmentEditor(ctx context.Context, username string, entityType model.EntityType,
+	entityID uuid.UUID) (model.Employee, error) {
+	switch entityType {
+	case model.EntityTender:
+		return s.tenderEditor(ctx, username, entityID, model.ActionTenderEdit)
+	case model.EntityBid:
+		employee, err := s.repository.Employee(ctx, username)
+		if err != nil {
+			return model.Employee{}, err
+		}
+
+		bid, err := s.Bid(ctx, username, entityID)
+		if err != nil {
+			return model.Employee{}, err
+		}
+
+		if !s.policy.Can(employee, model.ActionBidEdit, s.bidResource(bid)) {
+			return model.Employee{}, model.ErrNoRights
+		}
+
+		return employee, nil
+	default:
+		return model.Employee{}, model.ErrAttachmentNotFound
+	}
+}
+
+func (s *Service) discardAttachment(ctx context.Context, key string) {
+	err := s.storage.Delete(ctx, key)
+	if err != nil {
+		log.Error().Stack().Err(err).Str("key", key).Msg("discard attachment")
+	}
+}
+
+// attachmentType prefers the sniffed type and falls back to the declared one where sniffing cannot tell formats
+// apart: unknown binaries such as drawings, CSV read as plain text and office documents read as zip archives.
+func attachmentType(head []byte, declared string) string {
+	detected, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	declared, _, _ = mime.ParseMediaType(declared)
+
+	switch detected {
+	case "application/octet-stream":
+		return declared
+	case "text/plain":
+		if declared == "text/csv" {
+			return declared
+		}
+	case "application/zip":
+		if strings.HasPrefix(declared, "application/vnd.openxmlformats-officedocument.") {
+			return declared
+		}
+	}
+
+	return detected
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,41 @@
+package service
+
+import "testing"
+
+func TestAttachmentType(t *testing.T) {
+	var (
+		pdf  = []byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3\n")
+		png  = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+		zip  = []byte("PK\x03\x04\x14\x00\x06\x00")
+		html = []byte("<!DOCTYPE html><html><body>")
+		text = []byte("name,price\nsteel,100\n")
+		bin  = []byte{0x41, 0x43, 0x31, 0x30, 0x33, 0x32, 0x00, 0x00, 0x00, 0x00, 0x00, 0x06, 0x00, 0xa7, 0x01}
+	)
+
+	tests := []struct {
+		name     string
+		head     []byte
+		declared string
+		want     string
+	}{
+		{"sniffed type wins over the declared one", pdf, "image/png", "application/pdf"},
+		{"declared parameters are dropped", png, "image/png; name=plan.png", "image/png"},
+		{"html cannot pass as a document", html, "application/pdf", "text/html"},
+		{"text declared as csv", text, "text/csv; charset=utf-8", "text/csv"},
+		{"text declared as something else", text, "application/pdf", "text/plain"},
+		{"office document read as zip", zip,
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"},
+		{"zip declared as another type", zip, "application/pdf", "application/zip"},
+		{"unknown binary keeps the declared type", bin, "image/vnd.dwg", "image/vnd.dwg"},
+		{"unknown binary without a declared type", bin, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := attachmentType(tt.head, tt.declared); got != tt.want {
+				t.Errorf("attachmentType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
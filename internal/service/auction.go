@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+func (s *Service) Auction(ctx context.Context, username string, tenderID uuid.UUID) (model.Auction, error) {
+	_, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	return s.repository.Auction(ctx, tenderID)
+}
+
+func (s *Service) CreateAuction(ctx context.Context, username string, auction model.Auction) (model.Auction, error) {
+	if !auction.Ends.After(auction.Starts) || auction.Step <= 0 || auction.Extension < 0 {
+		return model.Auction{}, model.ErrInvalidAuction
+	}
+
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: auction.TenderID})
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderAuction, s.tenderResource(tender)) {
+		return model.Auction{}, model.ErrNoRights
+	}
+
+	auction.CreatorID = employee.ID
+
+	return s.repository.CreateAuction(ctx, auction)
+}
+
+func (s *Service) PlaceOffer(ctx context.Context, username string, bidID uuid.UUID, price float64) (model.Auction, error) {
+	if price <= 0 {
+		return model.Auction{}, model.ErrInvalidPrice
+	}
+
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	bid, err := s.Bid(ctx, username, bidID)
+	if err != nil {
+		return model.Auction{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionBidEdit, s.bidResource(bid)) {
+		return model.Auction{}, model.ErrNoRights
+	}
+
+	offer := model.AuctionOffer{
+		BidID:      bidID,
+		Price:      price,
+		EmployeeID: employee.ID,
+	}
+
+	return s.repository.PlaceOffer(ctx, offer)
+}
+
+// checkAuctionDecision keeps decisions on an auction tender until the window closes and lets only the best offer win.
+func (s *Service) checkAuctionDecision(ctx context.Context, tender model.Tender, bidID uuid.UUID, status model.BidStatus) error {
+	if tender.BidMode != model.TenderBidModeAuction {
+		return nil
+	}
+
+	auction, err := s.repository.Auction(ctx, tender.ID)
+	if err != nil {
+		return err
+	}
+
+	if !auction.Ended(time.Now()) {
+		return model.ErrAuctionInProgress
+	}
+
+	if status == model.BidStatusApproved && auction.BestBidID != bidID {
+		return model.ErrNotAuctionWinner
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"zadanie-6105/internal/model"
+)
+
+func (s *Service) Audit(ctx context.Context, username string, actor string, opts model.AuditFilter) ([]model.AuditEvent, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationIDs := s.policy.Organizations(employee, model.ActionAuditView)
+	if len(organizationIDs) == 0 {
+		return nil, model.ErrNoRights
+	}
+
+	if actor != "" {
+		a, err := s.repository.Employee(ctx, actor)
+		if err != nil {
+			// Nobody by that name has done anything; the requester is not the unknown user here.
+			if errors.Is(err, model.ErrUserNotFound) {
+				return []model.AuditEvent{}, nil
+			}
+			return nil, err
+		}
+
+		opts.ActorID = a.ID
+	}
+
+	opts.EmployeeID = employee.ID
+	opts.OrganizationIDs = organizationIDs
+
+	events, err := s.repository.AuditEvents(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
@@ -2,7 +2,7 @@ package service
 
 import (
 	"context"
-	"slices"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
@@ -20,7 +20,7 @@ func (s *Service) Bids(ctx context.Context, username string, opts model.BidFilte
 		opts.CreatorID = employee.ID
 	}
 
-	opts.OrganizationIDs = employee.OrganizationIDs
+	opts.OrganizationIDs = s.policy.Organizations(employee, model.ActionBidView)
 
 	bids, err := s.repository.Bids(ctx, opts)
 	if err != nil {
@@ -41,27 +41,37 @@ func (s *Service) Bid(ctx context.Context, username string, bidID uuid.UUID) (mo
 	}
 
 	if len(bids) == 0 {
-		return model.Bid{}, errors.New("TODO")
+		return model.Bid{}, model.ErrTenderOrBidNotFound
 	}
 
 	return bids[0], nil
 }
 
 func (s *Service) CreateBid(ctx context.Context, username string, bid model.Bid) (model.Bid, error) {
+	if bid.Price < 0 {
+		return model.Bid{}, model.ErrInvalidPrice
+	}
+
 	employee, err := s.repository.Employee(ctx, username)
 	if err != nil {
 		return model.Bid{}, err
 	}
 
-	if !slices.Contains(employee.OrganizationIDs, bid.OrganizationID) {
+	if !s.policy.Can(employee, model.ActionBidCreate, model.Resource{OrganizationID: bid.OrganizationID}) {
 		return model.Bid{}, model.ErrNoRights
 	}
 
+	err = s.checkBidConflict(ctx, employee, bid)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
 	bid.ID, err = uuid.NewV7()
 	if err != nil {
 		return model.Bid{}, errors.WithStack(err)
 	}
 
+	bid.LotIDs = uniqueIDs(bid.LotIDs)
 	bid.Status = model.BidStatusCreated
 	bid.CreatorID = employee.ID
 	if bid.CreatorType == "" {
@@ -77,11 +87,29 @@ func (s *Service) CreateBid(ctx context.Context, username string, bid model.Bid)
 }
 
 func (s *Service) UpdateBid(ctx context.Context, username string, bid model.Bid) (model.Bid, error) {
+	if bid.Status != "" && !bid.Status.Settable() {
+		return model.Bid{}, model.ErrInvalidBidStatus
+	}
+
 	employee, err := s.repository.Employee(ctx, username)
 	if err != nil {
 		return model.Bid{}, err
 	}
 
+	current, err := s.Bid(ctx, username, bid.ID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	action := model.ActionBidEdit
+	if bid.Status != "" {
+		action = model.ActionBidStatus
+	}
+
+	if !s.policy.Can(employee, action, s.bidResource(current)) {
+		return model.Bid{}, model.ErrNoRights
+	}
+
 	bid.CreatorID = employee.ID
 
 	b, err := s.repository.UpdateBid(ctx, bid)
@@ -92,13 +120,22 @@ func (s *Service) UpdateBid(ctx context.Context, username string, bid model.Bid)
 	return b, nil
 }
 
-func (s *Service) RollbackBid(ctx context.Context, username string, tenderID uuid.UUID, versionID int64) (model.Bid, error) {
+func (s *Service) RollbackBid(ctx context.Context, username string, bidID uuid.UUID, versionID int64) (model.Bid, error) {
 	employee, err := s.repository.Employee(ctx, username)
 	if err != nil {
 		return model.Bid{}, err
 	}
 
-	b, err := s.repository.RollbackBid(ctx, tenderID, versionID, employee.ID)
+	current, err := s.Bid(ctx, username, bidID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionBidRollback, s.bidResource(current)) {
+		return model.Bid{}, model.ErrNoRights
+	}
+
+	b, err := s.repository.RollbackBid(ctx, bidID, versionID, employee.ID)
 	if err != nil {
 		return model.Bid{}, err
 	}
@@ -106,16 +143,110 @@ func (s *Service) RollbackBid(ctx context.Context, username string, tenderID uui
 	return b, nil
 }
 
-func (s *Service) SubmitBidDecision(ctx context.Context, username string, bidID uuid.UUID, status model.BidStatus) (model.Bid, error) {
+// SubmitBidDecision votes on a bid, or on one of its lots. With onBehalfOf the employee votes in place of
+// an approver who has delegated to them, the vote then counts as the approver's.
+func (s *Service) SubmitBidDecision(ctx context.Context, username string, bidID, lotID uuid.UUID,
+	status model.BidStatus, onBehalfOf string) (model.Bid, error) {
+	if status != model.BidStatusApproved && status != model.BidStatusRejected {
+		return model.Bid{}, model.ErrInvalidDecision
+	}
+
 	employee, err := s.repository.Employee(ctx, username)
 	if err != nil {
 		return model.Bid{}, err
 	}
 
-	b, err := s.repository.SubmitBidDecision(ctx, bidID, employee, status)
+	bid, err := s.Bid(ctx, username, bidID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: bid.TenderID})
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	approver := employee
+	if onBehalfOf != "" {
+		approver, err = s.delegator(ctx, employee, onBehalfOf, tender.OrganizationID)
+		if err != nil {
+			return model.Bid{}, err
+		}
+
+		err = s.checkDecisionConflict(ctx, employee, tender, bid)
+		if err != nil {
+			return model.Bid{}, err
+		}
+	}
+
+	if !s.policy.Can(approver, model.ActionBidDecide, model.Resource{OrganizationID: tender.OrganizationID}) {
+		return model.Bid{}, model.ErrNoRights
+	}
+
+	err = s.checkDecisionConflict(ctx, approver, tender, bid)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	if tender.Sealed(time.Now()) {
+		return model.Bid{}, model.ErrBidsSealed
+	}
+
+	err = s.checkAuctionDecision(ctx, tender, bidID, status)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	err = s.checkLotDecision(ctx, tender.ID, lotID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	workflow, err := s.tenderWorkflow(ctx, tender)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	decision := model.BidDecision{
+		BidID:         bidID,
+		LotID:         lotID,
+		Employee:      approver,
+		Status:        status,
+		ApproverRoles: s.policy.Roles(model.ActionBidDecide),
+		Workflow:      workflow,
+	}
+
+	if approver.ID != employee.ID {
+		decision.DelegateID = employee.ID
+	}
+
+	b, err := s.repository.SubmitBidDecision(ctx, decision)
 	if err != nil {
 		return model.Bid{}, err
 	}
 
 	return b, nil
 }
+
+func (s *Service) bidResource(bid model.Bid) model.Resource {
+	return model.Resource{
+		OrganizationID: bid.OrganizationID,
+		CreatorID:      bid.CreatorID,
+	}
+}
+
+func uniqueIDs(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]struct{}, len(ids))
+	unique := make([]uuid.UUID, 0, len(ids))
+
+	for _, id := range ids {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+
+		seen[id] = struct{}{}
+		unique = append(unique, id)
+	}
+
+	return unique
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+func (s *Service) Blacklist(ctx context.Context, username string, organizationID uuid.UUID) ([]model.BlacklistEntry, error) {
+	_, err := s.blacklistManager(ctx, username, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.BlacklistEntries(ctx, organizationID)
+}
+
+// CreateBlacklistEntry bars either an organization or a single user from bidding on the tenders
+// of the organization. Bids they have already made stay, flagged in the bid list.
+func (s *Service) CreateBlacklistEntry(ctx context.Context, username string, entry model.BlacklistEntry,
+	blocked string) (model.BlacklistEntry, error) {
+	if (entry.BlockedOrganizationID == uuid.Nil) == (blocked == "") || entry.Reason == "" ||
+		!entry.Active(time.Now()) {
+		return model.BlacklistEntry{}, model.ErrInvalidBlacklistEntry
+	}
+
+	employee, err := s.blacklistManager(ctx, username, entry.OrganizationID)
+	if err != nil {
+		return model.BlacklistEntry{}, err
+	}
+
+	if blocked != "" {
+		e, err := s.repository.Employee(ctx, blocked)
+		if err != nil {
+			if errors.Is(err, model.ErrUserNotFound) {
+				return model.BlacklistEntry{}, model.ErrInvalidBlacklistEntry
+			}
+			return model.BlacklistEntry{}, err
+		}
+
+		entry.BlockedEmployeeID = e.ID
+	}
+
+	entry.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.BlacklistEntry{}, errors.WithStack(err)
+	}
+
+	entry.CreatorID = employee.ID
+
+	return s.repository.CreateBlacklistEntry(ctx, entry)
+}
+
+func (s *Service) DeleteBlacklistEntry(ctx context.Context, username string, organizationID, entryID uuid.UUID) error {
+	employee, err := s.blacklistManager(ctx, username, organizationID)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteBlacklistEntry(ctx, organizationID, entryID, employee.ID)
+}
+
+func (s *Service) blacklistManager(ctx context.Context, username string, organizationID uuid.UUID) (model.Employee, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionBlacklistManage, model.Resource{OrganizationID: organizationID}) {
+		return model.Employee{}, model.ErrNoRights
+	}
+
+	return employee, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+// Categories lists the taxonomy. It is the same for everyone, so no user is needed.
+func (s *Service) Categories(ctx context.Context) ([]model.Category, error) {
+	return s.repository.Categories(ctx)
+}
+
+func (s *Service) CreateCategory(ctx context.Context, username string, category model.Category) (model.Category, error) {
+	if category.Name == "" {
+		return model.Category{}, model.ErrInvalidCategory
+	}
+
+	employee, err := s.platformAdmin(ctx, username)
+	if err != nil {
+		return model.Category{}, err
+	}
+
+	category.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Category{}, errors.WithStack(err)
+	}
+
+	return s.repository.CreateCategory(ctx, category, employee.ID)
+}
+
+// UpdateCategory renames a category or moves it under another parent; empty fields are left as they are.
+func (s *Service) UpdateCategory(ctx context.Context, username string, category model.Category) (model.Category, error) {
+	employee, err := s.platformAdmin(ctx, username)
+	if err != nil {
+		return model.Category{}, err
+	}
+
+	return s.repository.UpdateCategory(ctx, category, employee.ID)
+}
+
+func (s *Service) DeleteCategory(ctx context.Context, username string, categoryID uuid.UUID) error {
+	employee, err := s.platformAdmin(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteCategory(ctx, categoryID, employee.ID)
+}
+
+// platformAdmin returns the employee when they may manage the taxonomy. Categories are shared by all
+// organizations, so no organization role is enough for that.
+func (s *Service) platformAdmin(ctx context.Context, username string) (model.Employee, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	if !employee.PlatformAdmin {
+		return model.Employee{}, model.ErrNoRights
+	}
+
+	return employee, nil
+}
+
+// category resolves the category of a tender, given either by ID or, as service types used to be, by the name
+// of a root category.
+func (s *Service) category(ctx context.Context, categoryID uuid.UUID, name string) (model.Category, error) {
+	if categoryID != uuid.Nil {
+		return s.repository.Category(ctx, categoryID)
+	}
+
+	if name != "" {
+		return s.repository.RootCategory(ctx, name)
+	}
+
+	return model.Category{}, errors.WithStack(model.ErrCategoryNotFound)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+func (s *Service) VerifyTender(ctx context.Context, username string, tenderID uuid.UUID) (model.ChainVerification, error) {
+	_, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return model.ChainVerification{}, err
+	}
+
+	return s.VerifyTenderChain(ctx, tenderID)
+}
+
+func (s *Service) VerifyTenderChain(ctx context.Context, tenderID uuid.UUID) (model.ChainVerification, error) {
+	versions, err := s.repository.TenderVersions(ctx, tenderID)
+	if err != nil {
+		return model.ChainVerification{}, err
+	}
+
+	if len(versions) == 0 {
+		return model.ChainVerification{}, model.ErrTenderOrVersionNotFound
+	}
+
+	links := make([]model.ChainLink, 0, len(versions))
+	for _, v := range versions {
+		links = append(links, v.Link())
+	}
+
+	return model.VerifyChain(links), nil
+}
+
+func (s *Service) VerifyBid(ctx context.Context, username string, bidID uuid.UUID) (model.ChainVerification, error) {
+	_, err := s.Bid(ctx, username, bidID)
+	if err != nil {
+		return model.ChainVerification{}, err
+	}
+
+	return s.VerifyBidChain(ctx, bidID)
+}
+
+func (s *Service) VerifyBidChain(ctx context.Context, bidID uuid.UUID) (model.ChainVerification, error) {
+	versions, err := s.repository.BidVersions(ctx, bidID)
+	if err != nil {
+		return model.ChainVerification{}, err
+	}
+
+	if len(versions) == 0 {
+		return model.ChainVerification{}, model.ErrTenderOrBidNotFound
+	}
+
+	links := make([]model.ChainLink, 0, len(versions))
+	for _, v := range versions {
+		links = append(links, v.Link())
+	}
+
+	return model.VerifyChain(links), nil
+}
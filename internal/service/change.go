@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"zadanie-6105/internal/model"
+)
+
+const (
+	changeBuffer        = 64
+	changeListenBackoff = time.Second
+)
+
+// RunChangeFeed relays committed changes from the database to every subscriber of this replica
+// and reconnects whenever the listening connection breaks.
+func (s *Service) RunChangeFeed(ctx context.Context) {
+	for {
+		err := s.repository.ListenChanges(ctx, func(change model.Change) {
+			s.scopeChange(ctx, change)
+		})
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Error().Stack().Err(err).Msg("change feed")
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(changeListenBackoff):
+		}
+	}
+}
+
+// scopeChange looks up who may see the change once for all subscribers and publishes it. A change whose scope
+// cannot be looked up is dropped, it would not be shown to anyone.
+func (s *Service) scopeChange(ctx context.Context, change model.Change) {
+	scope, err := s.repository.ChangeScope(ctx, change)
+	if err != nil {
+		log.Error().Stack().Err(err).Msg("change scope")
+		return
+	}
+
+	change.Scope = scope
+	s.changes.publish(change)
+}
+
+// Changes streams the changes of tenders and bids the employee may see until ctx is done.
+// A non-nil tenderID narrows the stream to that tender and its bids. The employee and their organizations
+// are resolved when subscribing, so role changes apply to new subscriptions.
+func (s *Service) Changes(ctx context.Context, username string, tenderID uuid.UUID) (<-chan model.Change, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	viewer := changeViewer{
+		employeeID:            employee.ID,
+		tenderOrganizationIDs: s.policy.Organizations(employee, model.ActionTenderView),
+		bidOrganizationIDs:    s.policy.Organizations(employee, model.ActionBidView),
+	}
+
+	in := s.changes.subscribe()
+	out := make(chan model.Change, changeBuffer)
+
+	go func() {
+		defer close(out)
+		defer s.changes.unsubscribe(in)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case change := <-in:
+				if tenderID != uuid.Nil && change.TenderID != tenderID {
+					continue
+				}
+
+				if !viewer.canSee(change) {
+					continue
+				}
+
+				select {
+				case out <- change:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// changeViewer applies the visibility rules of Tenders and Bids to the scope of a change.
+type changeViewer struct {
+	employeeID            uuid.UUID
+	tenderOrganizationIDs []uuid.UUID
+	bidOrganizationIDs    []uuid.UUID
+}
+
+func (v changeViewer) canSee(change model.Change) bool {
+	scope := change.Scope
+
+	switch change.EntityType {
+	case model.EntityTender, model.EntityAuction:
+		if slices.Contains(v.tenderOrganizationIDs, scope.TenderOrganizationID) {
+			return true
+		}
+
+		if !scope.TenderPublished {
+			return false
+		}
+
+		return scope.TenderPublic || slices.Contains(scope.InvitedEmployeeIDs, v.employeeID) ||
+			slices.ContainsFunc(scope.InvitedOrganizationIDs, func(id uuid.UUID) bool {
+				return slices.Contains(v.tenderOrganizationIDs, id)
+			})
+	case model.EntityBid:
+		if scope.BidStatus == "" {
+			return false
+		}
+
+		return slices.Contains(v.bidOrganizationIDs, scope.BidOrganizationID) ||
+			scope.BidStatus != model.BidStatusCreated && slices.Contains(v.bidOrganizationIDs, scope.TenderOrganizationID)
+	default:
+		return false
+	}
+}
+
+type changeBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan model.Change]struct{}
+}
+
+func newChangeBroker() *changeBroker {
+	return &changeBroker{
+		subscribers: make(map[chan model.Change]struct{}),
+	}
+}
+
+func (b *changeBroker) subscribe() chan model.Change {
+	ch := make(chan model.Change, changeBuffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	return ch
+}
+
+func (b *changeBroker) unsubscribe(ch chan model.Change) {
+	b.mu.Lock()
+	delete(b.subscribers, ch)
+	b.mu.Unlock()
+}
+
+// publish never blocks the feed: a subscriber that cannot keep up misses changes rather than stalling the others.
+func (b *changeBroker) publish(change model.Change) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- change:
+		default:
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+var mentionPattern = regexp.MustCompile(`(?:^|\s)@([\w.-]+)`)
+
+// Comments lists the thread of a bid. Bids are visible to their own organization and, once submitted, to the
+// tender organization, which are exactly the two sides of the discussion.
+func (s *Service) Comments(ctx context.Context, username string, opts model.CommentFilter) ([]model.Comment, error) {
+	employee, err := s.commentParticipant(ctx, username, opts.BidID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.EmployeeID = employee.ID
+
+	return s.repository.Comments(ctx, opts)
+}
+
+func (s *Service) CreateComment(ctx context.Context, username string, comment model.Comment) (model.Comment, error) {
+	comment.Body = strings.TrimSpace(comment.Body)
+	if comment.Body == "" || comment.BidVersionID < 0 {
+		return model.Comment{}, model.ErrInvalidComment
+	}
+
+	employee, err := s.commentParticipant(ctx, username, comment.BidID)
+	if err != nil {
+		return model.Comment{}, err
+	}
+
+	comment.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Comment{}, errors.WithStack(err)
+	}
+
+	comment.AuthorID = employee.ID
+
+	return s.repository.CreateComment(ctx, comment, mentions(comment.Body))
+}
+
+func (s *Service) MarkCommentsRead(ctx context.Context, username string, bidID uuid.UUID) error {
+	employee, err := s.commentParticipant(ctx, username, bidID)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.MarkCommentsRead(ctx, bidID, employee.ID)
+}
+
+// commentParticipant lets in whoever can see the bid. The tender organization joins the thread of a sealed
+// bid once the bids are opened.
+func (s *Service) commentParticipant(ctx context.Context, username string, bidID uuid.UUID) (model.Employee, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	bid, err := s.Bid(ctx, username, bidID)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	if bid.Sealed {
+		return model.Employee{}, model.ErrBidsSealed
+	}
+
+	return employee, nil
+}
+
+func mentions(body string) []string {
+	var usernames []string
+	for _, m := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		usernames = append(usernames, strings.TrimRight(m[1], ".-"))
+	}
+
+	return usernames
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+// comparisonPage is how many bids are read at once while building a comparison.
+const comparisonPage = 100
+
+// TenderComparison puts every bid of a tender the employee can see next to its votes and scores.
+// Only the tender organization evaluates bids, so the report is limited to it.
+func (s *Service) TenderComparison(ctx context.Context, username string, tenderID uuid.UUID) (model.Comparison, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Comparison{}, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return model.Comparison{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionBidView, model.Resource{OrganizationID: tender.OrganizationID}) {
+		return model.Comparison{}, model.ErrNoRights
+	}
+
+	criteria, err := s.repository.Criteria(ctx, tenderID)
+	if err != nil {
+		return model.Comparison{}, err
+	}
+
+	tallies, err := s.repository.BidTallies(ctx, tenderID)
+	if err != nil {
+		return model.Comparison{}, err
+	}
+
+	byBid := make(map[uuid.UUID]model.BidTally, len(tallies))
+	for _, t := range tallies {
+		byBid[t.BidID] = t
+	}
+
+	opts := model.BidFilter{
+		TenderID:        tenderID,
+		SortBy:          model.BidSortScore,
+		OrganizationIDs: s.policy.Organizations(employee, model.ActionBidView),
+		Limit:           comparisonPage,
+	}
+
+	comparison := model.Comparison{
+		Tender:   tender,
+		Criteria: criteria,
+	}
+
+	for {
+		bids, err := s.repository.Bids(ctx, opts)
+		if err != nil {
+			return model.Comparison{}, err
+		}
+
+		for _, b := range bids {
+			row := model.ComparisonRow{Bid: b, BidTally: byBid[b.ID]}
+			// Sealed bids are blanked out, their tally must not leak either.
+			if b.Sealed {
+				row.BidTally = model.BidTally{BidID: b.ID}
+			}
+
+			comparison.Rows = append(comparison.Rows, row)
+		}
+
+		if len(bids) < comparisonPage {
+			break
+		}
+
+		opts.Offset += comparisonPage
+	}
+
+	return comparison, nil
+}
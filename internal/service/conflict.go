@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+func (s *Service) ConflictExemptions(ctx context.Context, username string,
+	organizationID uuid.UUID) ([]model.ConflictExemption, error) {
+	_, err := s.exemptionManager(ctx, username, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.ConflictExemptions(ctx, organizationID)
+}
+
+// CreateConflictExemption exempts an employee of the organization from the conflict of interest guard
+// on its tenders.
+func (s *Service) CreateConflictExemption(ctx context.Context, username string, exemption model.ConflictExemption,
+	exempted string) (model.ConflictExemption, error) {
+	if exemption.Reason == "" {
+		return model.ConflictExemption{}, model.ErrInvalidExemption
+	}
+
+	employee, err := s.exemptionManager(ctx, username, exemption.OrganizationID)
+	if err != nil {
+		return model.ConflictExemption{}, err
+	}
+
+	e, err := s.repository.Employee(ctx, exempted)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.ConflictExemption{}, model.ErrInvalidExemption
+		}
+		return model.ConflictExemption{}, err
+	}
+
+	exemption.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.ConflictExemption{}, errors.WithStack(err)
+	}
+
+	exemption.EmployeeID = e.ID
+	exemption.CreatorID = employee.ID
+
+	return s.repository.CreateConflictExemption(ctx, exemption)
+}
+
+func (s *Service) DeleteConflictExemption(ctx context.Context, username string, organizationID,
+	exemptionID uuid.UUID) error {
+	employee, err := s.exemptionManager(ctx, username, organizationID)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteConflictExemption(ctx, organizationID, exemptionID, employee.ID)
+}
+
+func (s *Service) exemptionManager(ctx context.Context, username string, organizationID uuid.UUID) (model.Employee, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionConflictManage, model.Resource{OrganizationID: organizationID}) {
+		return model.Employee{}, model.ErrNoRights
+	}
+
+	return employee, nil
+}
+
+// checkBidConflict blocks a bid by an employee who also belongs to the tender organization, whichever
+// organization the bid is made for.
+func (s *Service) checkBidConflict(ctx context.Context, employee model.Employee, bid model.Bid) error {
+	tender, err := s.Tender(ctx, employee.Username, model.TenderFilter{TenderID: bid.TenderID})
+	if err != nil {
+		// Members of the tender organization always see its tenders, so a hidden tender is no conflict.
+		// Whether it can be bid on is up to CreateBid.
+		if errors.Is(err, model.ErrTenderOrVersionNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if !slices.Contains(employee.OrganizationIDs, tender.OrganizationID) {
+		return nil
+	}
+
+	return s.blockConflict(ctx, model.Conflict{
+		Action:               model.ActionBidCreate,
+		EmployeeID:           employee.ID,
+		TenderID:             tender.ID,
+		TenderOrganizationID: tender.OrganizationID,
+		BidOrganizationID:    bid.OrganizationID,
+	})
+}
+
+// checkDecisionConflict blocks an approver from deciding on a bid of another organization they belong to
+// or on their own bid.
+func (s *Service) checkDecisionConflict(ctx context.Context, employee model.Employee, tender model.Tender,
+	bid model.Bid) error {
+	if bid.CreatorID != employee.ID && !slices.Contains(employee.OrganizationIDs, bid.OrganizationID) {
+		return nil
+	}
+
+	return s.blockConflict(ctx, model.Conflict{
+		Action:               model.ActionBidDecide,
+		EmployeeID:           employee.ID,
+		TenderID:             tender.ID,
+		TenderOrganizationID: tender.OrganizationID,
+		BidID:                bid.ID,
+		BidOrganizationID:    bid.OrganizationID,
+	})
+}
+
+// blockConflict lets exempted employees through and records everyone else's attempt before refusing it.
+func (s *Service) blockConflict(ctx context.Context, conflict model.Conflict) error {
+	exempt, err := s.repository.ConflictExempt(ctx, conflict.TenderOrganizationID, conflict.EmployeeID)
+	if err != nil {
+		return err
+	}
+
+	if exempt {
+		return nil
+	}
+
+	err = s.repository.RecordConflict(ctx, conflict)
+	if err != nil {
+		return err
+	}
+
+	return model.ErrConflictOfInterest
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+func (s *Service) Criteria(ctx context.Context, username string, tenderID uuid.UUID) ([]model.Criterion, error) {
+	_, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.Criteria(ctx, tenderID)
+}
+
+func (s *Service) ReplaceCriteria(ctx context.Context, username string, tenderID uuid.UUID,
+	criteria []model.Criterion) ([]model.Criterion, error) {
+	for _, c := range criteria {
+		if c.Name == "" || c.Weight < model.MinCriterionWeight || c.Weight > model.MaxCriterionWeight {
+			return nil, model.ErrInvalidCriteria
+		}
+	}
+
+	employee, err := s.tenderEditor(ctx, username, tenderID, model.ActionTenderEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range criteria {
+		criteria[i].ID, err = uuid.NewV7()
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return s.repository.ReplaceCriteria(ctx, tenderID, criteria, employee.ID)
+}
+
+func (s *Service) BidScores(ctx context.Context, username string, bidID uuid.UUID) ([]model.Score, error) {
+	_, _, err := s.bidScorer(ctx, username, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.Scores(ctx, bidID)
+}
+
+// ScoreBid saves the marks of an approver. Each approver keeps their own scores, the bid total averages them.
+func (s *Service) ScoreBid(ctx context.Context, username string, bidID uuid.UUID, scores []model.Score) ([]model.Score, error) {
+	if len(scores) == 0 {
+		return nil, model.ErrInvalidScore
+	}
+
+	for _, sc := range scores {
+		if sc.Score < 0 || sc.Score > model.MaxScore {
+			return nil, model.ErrInvalidScore
+		}
+	}
+
+	employee, tender, err := s.bidScorer(ctx, username, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tender.Sealed(time.Now()) {
+		return nil, model.ErrBidsSealed
+	}
+
+	return s.repository.SaveScores(ctx, bidID, employee.ID, scores)
+}
+
+// bidScorer resolves an employee allowed to decide on the bid, scoring follows the same rights.
+func (s *Service) bidScorer(ctx context.Context, username string, bidID uuid.UUID) (model.Employee, model.Tender, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Employee{}, model.Tender{}, err
+	}
+
+	bid, err := s.Bid(ctx, username, bidID)
+	if err != nil {
+		return model.Employee{}, model.Tender{}, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: bid.TenderID})
+	if err != nil {
+		return model.Employee{}, model.Tender{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionBidDecide, model.Resource{OrganizationID: tender.OrganizationID}) {
+		return model.Employee{}, model.Tender{}, model.ErrNoRights
+	}
+
+	return employee, tender, nil
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+// Delegations lists the current and upcoming delegations of the organization to its members.
+func (s *Service) Delegations(ctx context.Context, username string, organizationID uuid.UUID) ([]model.Delegation, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderView, model.Resource{OrganizationID: organizationID}) {
+		return nil, model.ErrNoRights
+	}
+
+	return s.repository.Delegations(ctx, organizationID)
+}
+
+// CreateDelegation lets a colleague decide on bids in place of an approver until the delegation ends.
+// Approvers delegate their own rights, admins may delegate on behalf of any approver of the organization.
+func (s *Service) CreateDelegation(ctx context.Context, username string, delegation model.Delegation, delegator,
+	delegate string) (model.Delegation, error) {
+	now := time.Now()
+
+	if delegation.Starts.IsZero() {
+		delegation.Starts = now
+	}
+
+	if delegate == "" || !delegation.Ends.After(delegation.Starts) || !delegation.Ends.After(now) {
+		return model.Delegation{}, model.ErrInvalidDelegation
+	}
+
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Delegation{}, err
+	}
+
+	resource := model.Resource{OrganizationID: delegation.OrganizationID}
+
+	approver := employee
+	if delegator != "" && delegator != employee.Username {
+		if !s.policy.Can(employee, model.ActionDelegationManage, resource) {
+			return model.Delegation{}, model.ErrNoRights
+		}
+
+		approver, err = s.delegationMember(ctx, delegator, delegation.OrganizationID)
+		if err != nil {
+			return model.Delegation{}, err
+		}
+
+		if !s.policy.Can(approver, model.ActionBidDecide, resource) {
+			return model.Delegation{}, model.ErrInvalidDelegation
+		}
+	} else if !s.policy.Can(employee, model.ActionBidDecide, resource) {
+		return model.Delegation{}, model.ErrNoRights
+	}
+
+	substitute, err := s.delegationMember(ctx, delegate, delegation.OrganizationID)
+	if err != nil {
+		return model.Delegation{}, err
+	}
+
+	if substitute.ID == approver.ID {
+		return model.Delegation{}, model.ErrInvalidDelegation
+	}
+
+	delegation.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Delegation{}, errors.WithStack(err)
+	}
+
+	delegation.DelegatorID = approver.ID
+	delegation.DelegateID = substitute.ID
+	delegation.CreatorID = employee.ID
+
+	return s.repository.CreateDelegation(ctx, delegation)
+}
+
+// DeleteDelegation ends a delegation for good, by the approver who delegated or by an admin.
+func (s *Service) DeleteDelegation(ctx context.Context, username string, organizationID, delegationID uuid.UUID) error {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(employee.OrganizationIDs, organizationID) {
+		return model.ErrNoRights
+	}
+
+	delegation, err := s.repository.Delegation(ctx, organizationID, delegationID)
+	if err != nil {
+		return err
+	}
+
+	if delegation.DelegatorID != employee.ID &&
+		!s.policy.Can(employee, model.ActionDelegationManage, model.Resource{OrganizationID: organizationID}) {
+		return model.ErrNoRights
+	}
+
+	return s.repository.DeleteDelegation(ctx, organizationID, delegationID, employee.ID)
+}
+
+// delegator is the approver the employee decides for, provided the approver has delegated to them
+// in the organization for the present moment.
+func (s *Service) delegator(ctx context.Context, employee model.Employee, username string,
+	organizationID uuid.UUID) (model.Employee, error) {
+	if !slices.Contains(employee.OrganizationIDs, organizationID) {
+		return model.Employee{}, model.ErrNotDelegated
+	}
+
+	approver, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.Employee{}, model.ErrNotDelegated
+		}
+		return model.Employee{}, err
+	}
+
+	delegated, err := s.repository.Delegated(ctx, organizationID, approver.ID, employee.ID, time.Now())
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	if !delegated {
+		return model.Employee{}, model.ErrNotDelegated
+	}
+
+	return approver, nil
+}
+
+func (s *Service) delegationMember(ctx context.Context, username string, organizationID uuid.UUID) (model.Employee, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.Employee{}, model.ErrInvalidDelegation
+		}
+		return model.Employee{}, err
+	}
+
+	if !slices.Contains(employee.OrganizationIDs, organizationID) {
+		return model.Employee{}, model.ErrInvalidDelegation
+	}
+
+	return employee, nil
+}
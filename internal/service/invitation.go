@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+func (s *Service) Invitations(ctx context.Context, username string, opts model.InvitationFilter) ([]model.Invitation, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: opts.TenderID})
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderInvite, s.tenderResource(tender)) {
+		return nil, model.ErrNoRights
+	}
+
+	return s.repository.Invitations(ctx, opts)
+}
+
+// MyInvitations lists invitations addressed to the employee personally or to any organization they may answer for.
+func (s *Service) MyInvitations(ctx context.Context, username string, opts model.InvitationFilter) ([]model.Invitation, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.TenderID = uuid.Nil
+	opts.EmployeeID = employee.ID
+	opts.OrganizationIDs = s.policy.Organizations(employee, model.ActionInvitationView)
+
+	return s.repository.Invitations(ctx, opts)
+}
+
+func (s *Service) CreateInvitation(ctx context.Context, username string, invitation model.Invitation, invitee string) (model.Invitation, error) {
+	if (invitation.OrganizationID == uuid.Nil) == (invitee == "") {
+		return model.Invitation{}, model.ErrInvalidInvitation
+	}
+
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: invitation.TenderID})
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderInvite, s.tenderResource(tender)) {
+		return model.Invitation{}, model.ErrNoRights
+	}
+
+	if invitee != "" {
+		e, err := s.repository.Employee(ctx, invitee)
+		if err != nil {
+			if errors.Is(err, model.ErrUserNotFound) {
+				return model.Invitation{}, model.ErrInvalidInvitation
+			}
+			return model.Invitation{}, err
+		}
+
+		invitation.EmployeeID = e.ID
+	}
+
+	invitation.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Invitation{}, errors.WithStack(err)
+	}
+
+	invitation.Status = model.InvitationStatusPending
+	invitation.InviterID = employee.ID
+
+	return s.repository.CreateInvitation(ctx, invitation)
+}
+
+func (s *Service) DeleteInvitation(ctx context.Context, username string, tenderID, invitationID uuid.UUID) error {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderInvite, s.tenderResource(tender)) {
+		return model.ErrNoRights
+	}
+
+	invitations, err := s.repository.Invitations(ctx, model.InvitationFilter{InvitationID: invitationID, TenderID: tenderID})
+	if err != nil {
+		return err
+	}
+
+	if len(invitations) == 0 {
+		return model.ErrInvitationNotFound
+	}
+
+	return s.repository.DeleteInvitation(ctx, invitationID)
+}
+
+func (s *Service) RespondInvitation(ctx context.Context, username string, invitationID uuid.UUID, status model.InvitationStatus) (model.Invitation, error) {
+	if status != model.InvitationStatusAccepted && status != model.InvitationStatusDeclined {
+		return model.Invitation{}, model.ErrInvalidDecision
+	}
+
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	invitations, err := s.repository.Invitations(ctx, model.InvitationFilter{InvitationID: invitationID})
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	if len(invitations) == 0 {
+		return model.Invitation{}, model.ErrInvitationNotFound
+	}
+
+	invitation := invitations[0]
+
+	switch {
+	case invitation.EmployeeID != uuid.Nil:
+		if invitation.EmployeeID != employee.ID {
+			return model.Invitation{}, model.ErrInvitationNotFound
+		}
+	case !s.policy.Can(employee, model.ActionInvitationRespond, model.Resource{OrganizationID: invitation.OrganizationID}):
+		return model.Invitation{}, model.ErrNoRights
+	}
+
+	return s.repository.RespondInvitation(ctx, invitationID, status)
+}
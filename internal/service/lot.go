@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+func (s *Service) Lots(ctx context.Context, username string, tenderID uuid.UUID) ([]model.Lot, error) {
+	_, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.Lots(ctx, tenderID)
+}
+
+func (s *Service) CreateLot(ctx context.Context, username string, lot model.Lot) (model.Lot, error) {
+	if lot.Name == "" {
+		return model.Lot{}, model.ErrInvalidLot
+	}
+
+	employee, err := s.tenderEditor(ctx, username, lot.TenderID, model.ActionTenderEdit)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	lot.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Lot{}, errors.WithStack(err)
+	}
+
+	return s.repository.CreateLot(ctx, lot, employee.ID)
+}
+
+func (s *Service) UpdateLot(ctx context.Context, username string, lot model.Lot) (model.Lot, error) {
+	if lot.Name == "" && lot.Description == "" {
+		return model.Lot{}, model.ErrInvalidLot
+	}
+
+	employee, err := s.tenderEditor(ctx, username, lot.TenderID, model.ActionTenderEdit)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	return s.repository.UpdateLot(ctx, lot, employee.ID)
+}
+
+func (s *Service) RemoveLot(ctx context.Context, username string, tenderID, lotID uuid.UUID) (model.Lot, error) {
+	employee, err := s.tenderEditor(ctx, username, tenderID, model.ActionTenderEdit)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	return s.repository.SetLotStatus(ctx, tenderID, lotID, model.LotStatusRemoved, employee.ID)
+}
+
+func (s *Service) CancelLot(ctx context.Context, username string, tenderID, lotID uuid.UUID) (model.Lot, error) {
+	employee, err := s.tenderEditor(ctx, username, tenderID, model.ActionTenderStatus)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	return s.repository.SetLotStatus(ctx, tenderID, lotID, model.LotStatusCanceled, employee.ID)
+}
+
+func (s *Service) tenderEditor(ctx context.Context, username string, tenderID uuid.UUID, action model.Action) (model.Employee, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	if !s.policy.Can(employee, action, s.tenderResource(tender)) {
+		return model.Employee{}, model.ErrNoRights
+	}
+
+	return employee, nil
+}
+
+// checkLotDecision makes decisions on a tender split into lots name the lot they are about.
+func (s *Service) checkLotDecision(ctx context.Context, tenderID, lotID uuid.UUID) error {
+	lots, err := s.repository.Lots(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+
+	if lotID == uuid.Nil && len(lots) > 0 {
+		return model.ErrLotRequired
+	}
+
+	if lotID != uuid.Nil && len(lots) == 0 {
+		return model.ErrLotNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"zadanie-6105/internal/model"
+)
+
+// Notifications is the in-app inbox of the employee, newest first.
+func (s *Service) Notifications(ctx context.Context, username string, opts model.NotificationFilter) ([]model.Notification, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	opts.EmployeeID = employee.ID
+
+	return s.repository.Notifications(ctx, opts)
+}
+
+func (s *Service) MarkNotificationRead(ctx context.Context, username string, notificationID int64) (model.Notification, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Notification{}, err
+	}
+
+	return s.repository.MarkNotificationRead(ctx, employee.ID, notificationID)
+}
+
+func (s *Service) MarkNotificationsRead(ctx context.Context, username string) error {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.MarkNotificationsRead(ctx, employee.ID)
+}
+
+// NotificationPreferences returns a preference for every notified event type, filling in the defaults.
+func (s *Service) NotificationPreferences(ctx context.Context, username string) ([]model.NotificationPreference, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.notificationPreferences(ctx, employee)
+}
+
+// UpdateNotificationPreferences changes the given event types only, the others keep their preference.
+func (s *Service) UpdateNotificationPreferences(ctx context.Context, username string,
+	preferences []model.NotificationPreference) ([]model.NotificationPreference, error) {
+	for _, p := range preferences {
+		if !slices.Contains(model.NotificationEventTypes, p.EventType) {
+			return nil, model.ErrInvalidNotificationPreference
+		}
+	}
+
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.repository.SaveNotificationPreferences(ctx, employee.ID, preferences)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.notificationPreferences(ctx, employee)
+}
+
+func (s *Service) notificationPreferences(ctx context.Context, employee model.Employee) ([]model.NotificationPreference, error) {
+	stored, err := s.repository.NotificationPreferences(ctx, employee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	preferences := make([]model.NotificationPreference, 0, len(model.NotificationEventTypes))
+	for _, t := range model.NotificationEventTypes {
+		p := model.NotificationPreference{EventType: t, InApp: true, Email: true}
+
+		i := slices.IndexFunc(stored, func(sp model.NotificationPreference) bool { return sp.EventType == t })
+		if i >= 0 {
+			p = stored[i]
+		}
+
+		preferences = append(preferences, p)
+	}
+
+	return preferences, nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"os"
+	"slices"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+//go:embed policy.json
+var defaultPolicy []byte
+
+var roles = []model.Role{model.RoleViewer, model.RoleEditor, model.RoleApprover, model.RoleAdmin}
+
+// Policy answers whether an employee may perform an action on a resource. Rules for the same action are
+// alternatives: any matching rule grants access. A rule matches when the employee holds one of its roles
+// in the resource's organization and, for owner rules, also created the resource.
+type Policy struct {
+	rules map[model.Action][]policyRule
+}
+
+type policyRule struct {
+	Action model.Action `json:"action"`
+	Roles  []model.Role `json:"roles"`
+	Owner  bool         `json:"owner"`
+}
+
+type policyFile struct {
+	Rules []policyRule `json:"rules"`
+}
+
+func DefaultPolicy() *Policy {
+	p, err := ParsePolicy(defaultPolicy)
+	if err != nil {
+		panic(err)
+	}
+
+	return p
+}
+
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return ParsePolicy(data)
+}
+
+func ParsePolicy(data []byte) (*Policy, error) {
+	var f policyFile
+
+	err := json.Unmarshal(data, &f)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse policy")
+	}
+
+	p := &Policy{rules: make(map[model.Action][]policyRule)}
+	for _, rule := range f.Rules {
+		for _, role := range rule.Roles {
+			if !slices.Contains(roles, role) {
+				return nil, errors.Newf("policy rule for %q: unknown role %q", rule.Action, role)
+			}
+		}
+
+		p.rules[rule.Action] = append(p.rules[rule.Action], rule)
+	}
+
+	return p, nil
+}
+
+func (p *Policy) Can(employee model.Employee, action model.Action, resource model.Resource) bool {
+	role, ok := employee.Roles[resource.OrganizationID]
+	if !ok {
+		return false
+	}
+
+	for _, rule := range p.rules[action] {
+		if !slices.Contains(rule.Roles, role) {
+			continue
+		}
+
+		if rule.Owner && resource.CreatorID != employee.ID {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// Organizations lists the organizations in which the employee may perform the action on at least some resources,
+// which is what listings filter by.
+func (p *Policy) Organizations(employee model.Employee, action model.Action) []uuid.UUID {
+	organizationIDs := make([]uuid.UUID, 0, len(employee.OrganizationIDs))
+	for _, organizationID := range employee.OrganizationIDs {
+		role := employee.Roles[organizationID]
+
+		for _, rule := range p.rules[action] {
+			if slices.Contains(rule.Roles, role) {
+				organizationIDs = append(organizationIDs, organizationID)
+				break
+			}
+		}
+	}
+
+	return organizationIDs
+}
+
+// Roles lists every role that some rule grants the action to.
+func (p *Policy) Roles(action model.Action) []model.Role {
+	var granted []model.Role
+	for _, rule := range p.rules[action] {
+		for _, role := range rule.Roles {
+			if !slices.Contains(granted, role) {
+				granted = append(granted, role)
+			}
+		}
+	}
+
+	return granted
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+type policyGrant struct {
+	action model.Action
+	roles  []model.Role
+	owner  []model.Role
+}
+
+// policyGrants is what the embedded policy is meant to say: the roles that may perform an action on any resource
+// of their organization and the roles that may only do so on resources they created themselves.
+var policyGrants = []policyGrant{
+	{model.ActionTenderView, []model.Role{model.RoleViewer, model.RoleEditor, model.RoleApprover, model.RoleAdmin}, nil},
+	{model.ActionTenderCreate, []model.Role{model.RoleEditor, model.RoleAdmin}, nil},
+	{model.ActionTenderEdit, []model.Role{model.RoleEditor, model.RoleAdmin}, nil},
+	{model.ActionTenderStatus, []model.Role{model.RoleEditor, model.RoleAdmin}, nil},
+	{model.ActionTenderRollback, []model.Role{model.RoleEditor, model.RoleAdmin}, nil},
+	{model.ActionTenderTransfer, []model.Role{model.RoleAdmin}, []model.Role{model.RoleEditor}},
+	{model.ActionTenderInvite, []model.Role{model.RoleEditor, model.RoleAdmin}, nil},
+	{model.ActionTenderOpenBids, []model.Role{model.RoleEditor, model.RoleAdmin}, nil},
+	{model.ActionTenderAuction, []model.Role{model.RoleEditor, model.RoleAdmin}, nil},
+	{model.ActionTenderAnswer, []model.Role{model.RoleEditor, model.RoleApprover, model.RoleAdmin}, nil},
+	{model.ActionInvitationView, []model.Role{model.RoleViewer, model.RoleEditor, model.RoleApprover, model.RoleAdmin}, nil},
+	{model.ActionInvitationRespond, []model.Role{model.RoleEditor, model.RoleAdmin}, nil},
+	{model.ActionBidView, []model.Role{model.RoleViewer, model.RoleEditor, model.RoleApprover, model.RoleAdmin}, nil},
+	{model.ActionBidCreate, []model.Role{model.RoleEditor, model.RoleAdmin}, nil},
+	{model.ActionBidEdit, nil, []model.Role{model.RoleEditor, model.RoleAdmin}},
+	{model.ActionBidStatus, nil, []model.Role{model.RoleEditor, model.RoleAdmin}},
+	{model.ActionBidRollback, nil, []model.Role{model.RoleEditor, model.RoleAdmin}},
+	{model.ActionBidDecide, []model.Role{model.RoleApprover, model.RoleAdmin}, nil},
+	{model.ActionBidReview, []model.Role{model.RoleEditor, model.RoleApprover, model.RoleAdmin}, nil},
+	{model.ActionAuditView, []model.Role{model.RoleAdmin}, nil},
+	{model.ActionWebhookManage, []model.Role{model.RoleAdmin}, nil},
+	{model.ActionConflictManage, []model.Role{model.RoleAdmin}, nil},
+	{model.ActionBlacklistManage, []model.Role{model.RoleAdmin}, nil},
+	{model.ActionWorkflowManage, []model.Role{model.RoleAdmin}, nil},
+	{model.ActionDelegationManage, []model.Role{model.RoleAdmin}, nil},
+}
+
+func TestDefaultPolicyCan(t *testing.T) {
+	policy := DefaultPolicy()
+
+	own, other := uuid.New(), uuid.New()
+
+	for _, grant := range policyGrants {
+		for _, role := range roles {
+			for _, organizationID := range []uuid.UUID{own, other} {
+				for _, creator := range []bool{true, false} {
+					employee := model.Employee{
+						ID:              uuid.New(),
+						OrganizationIDs: []uuid.UUID{own},
+						Roles:           map[uuid.UUID]model.Role{own: role},
+					}
+
+					resource := model.Resource{OrganizationID: organizationID, CreatorID: uuid.New()}
+					if creator {
+						resource.CreatorID = employee.ID
+					}
+
+					want := organizationID == own &&
+						(slices.Contains(grant.roles, role) || (creator && slices.Contains(grant.owner, role)))
+
+					name := fmt.Sprintf("%s/%s/own=%t/creator=%t", grant.action, role, organizationID == own, creator)
+					if got := policy.Can(employee, grant.action, resource); got != want {
+						t.Errorf("%s: Can() = %t, want %t", name, got, want)
+					}
+				}
+			}
+		}
+	}
+}
+
+// Memberships added without a role are viewers, who may look at their organization's tenders and bids but
+// change nothing.
+func TestDefaultPolicyViewer(t *testing.T) {
+	policy := DefaultPolicy()
+
+	organizationID := uuid.New()
+	viewer := model.Employee{
+		ID:              uuid.New(),
+		OrganizationIDs: []uuid.UUID{organizationID},
+		Roles:           map[uuid.UUID]model.Role{organizationID: model.RoleViewer},
+	}
+
+	views := []model.Action{model.ActionTenderView, model.ActionBidView, model.ActionInvitationView}
+
+	for _, grant := range policyGrants {
+		resource := model.Resource{OrganizationID: organizationID, CreatorID: viewer.ID}
+
+		if got, want := policy.Can(viewer, grant.action, resource), slices.Contains(views, grant.action); got != want {
+			t.Errorf("viewer %s: Can() = %t, want %t", grant.action, got, want)
+		}
+	}
+
+	if got := policy.Organizations(viewer, model.ActionBidView); !slices.Equal(got, []uuid.UUID{organizationID}) {
+		t.Errorf("viewer Organizations(bid.view) = %v", got)
+	}
+
+	if got := policy.Organizations(viewer, model.ActionBidCreate); len(got) != 0 {
+		t.Errorf("viewer Organizations(bid.create) = %v, want none", got)
+	}
+}
+
+func TestDefaultPolicyCoversGrants(t *testing.T) {
+	policy := DefaultPolicy()
+
+	for action := range policy.rules {
+		if !slices.ContainsFunc(policyGrants, func(g policyGrant) bool { return g.action == action }) {
+			t.Errorf("policy has rules for %s that the test does not cover", action)
+		}
+	}
+}
+
+func TestParsePolicyRejectsUnknownRole(t *testing.T) {
+	_, err := ParsePolicy([]byte(`{"rules": [{"action": "tender.view", "roles": ["owner"]}]}`))
+	if err == nil {
+		t.Error("ParsePolicy() accepted an unknown role")
+	}
+
+	_, err = ParsePolicy([]byte(`{"rules": [`))
+	if err == nil {
+		t.Error("ParsePolicy() accepted malformed json")
+	}
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"zadanie-6105/internal/model"
+)
+
+const (
+	publicationInterval = 10 * time.Second
+	publicationBatch    = 20
+	publicationLease    = time.Minute
+)
+
+// ScheduleTenderPublication sets or moves the moment a created tender goes live. It needs the same right
+// as publishing the tender by hand.
+func (s *Service) ScheduleTenderPublication(ctx context.Context, username string, tenderID uuid.UUID,
+	publishAt time.Time) (model.Tender, error) {
+	if !publishAt.After(time.Now()) {
+		return model.Tender{}, model.ErrInvalidPublishAt
+	}
+
+	employee, err := s.tenderEditor(ctx, username, tenderID, model.ActionTenderStatus)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	return s.repository.ScheduleTenderPublication(ctx, tenderID, publishAt, employee.ID)
+}
+
+func (s *Service) CancelTenderPublication(ctx context.Context, username string, tenderID uuid.UUID) (model.Tender, error) {
+	employee, err := s.tenderEditor(ctx, username, tenderID, model.ActionTenderStatus)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	return s.repository.CancelTenderPublication(ctx, tenderID, employee.ID)
+}
+
+// RunScheduledPublications publishes tenders whose time has come. Every replica may run it, claims keep them
+// from publishing the same tender twice.
+func (s *Service) RunScheduledPublications(ctx context.Context) {
+	ticker := time.NewTicker(publicationInterval)
+	defer ticker.Stop()
+
+	for {
+		err := s.publishScheduled(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("scheduled publication")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) publishScheduled(ctx context.Context) error {
+	publications, err := s.repository.ClaimScheduledPublications(ctx, publicationBatch, publicationLease)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range publications {
+		err = s.publish(ctx, p)
+		if err != nil && !errors.Is(err, model.ErrPublicationNotScheduled) {
+			log.Warn().Err(err).Str("tender", p.Tender.ID.String()).Msg("publish scheduled tender")
+		}
+	}
+
+	return nil
+}
+
+// publish checks the rights of the scheduling employee as of now, a schedule they may no longer carry out
+// is canceled on their behalf.
+func (s *Service) publish(ctx context.Context, publication model.ScheduledPublication) error {
+	employee, err := s.repository.Employee(ctx, publication.Username)
+	if err != nil {
+		return err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderStatus, s.tenderResource(publication.Tender)) {
+		_, err = s.repository.CancelTenderPublication(ctx, publication.Tender.ID, employee.ID)
+		if err != nil {
+			return err
+		}
+
+		return model.ErrNoRights
+	}
+
+	_, err = s.repository.PublishScheduledTender(ctx, publication)
+
+	return err
+}
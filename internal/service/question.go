@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+func (s *Service) Questions(ctx context.Context, username string, opts model.QuestionFilter) ([]model.Question, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: opts.TenderID})
+	if err != nil {
+		return nil, err
+	}
+
+	opts.AuthorID = employee.ID
+	opts.All = s.policy.Can(employee, model.ActionTenderView, model.Resource{OrganizationID: tender.OrganizationID})
+
+	return s.repository.Questions(ctx, opts)
+}
+
+func (s *Service) AskQuestion(ctx context.Context, username string, question model.Question) (model.Question, error) {
+	if question.Question == "" {
+		return model.Question{}, model.ErrInvalidQuestion
+	}
+
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Question{}, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: question.TenderID})
+	if err != nil {
+		return model.Question{}, err
+	}
+
+	if tender.Status != model.TenderStatusPublished {
+		return model.Question{}, model.ErrQuestionsClosed
+	}
+
+	question.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Question{}, errors.WithStack(err)
+	}
+
+	question.AuthorID = employee.ID
+
+	return s.repository.CreateQuestion(ctx, question, tender.OrganizationID)
+}
+
+// AnswerQuestion answers on behalf of the tender organization. A non-empty change also edits the tender,
+// which takes the same rights as a regular tender edit.
+func (s *Service) AnswerQuestion(ctx context.Context, username string, answer model.Question,
+	change model.Tender) (model.Question, error) {
+	if answer.Answer == "" || !answer.Visibility.Valid() {
+		return model.Question{}, model.ErrInvalidAnswer
+	}
+
+	employee, err := s.tenderEditor(ctx, username, answer.TenderID, model.ActionTenderAnswer)
+	if err != nil {
+		return model.Question{}, err
+	}
+
+	if change.Name != "" || change.Description != "" || change.CategoryID != uuid.Nil || change.Category != "" {
+		_, err = s.tenderEditor(ctx, username, answer.TenderID, model.ActionTenderEdit)
+		if err != nil {
+			return model.Question{}, err
+		}
+
+		if change.CategoryID != uuid.Nil || change.Category != "" {
+			category, err := s.category(ctx, change.CategoryID, change.Category)
+			if err != nil {
+				return model.Question{}, err
+			}
+
+			change.CategoryID = category.ID
+		}
+
+		change.ID = answer.TenderID
+	}
+
+	answer.AnswererID = employee.ID
+
+	return s.repository.AnswerQuestion(ctx, answer, change)
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+
+	"zadanie-6105/internal/model"
+)
+
+const (
+	reputationInterval = 15 * time.Minute
+	maxFeedbackLength  = 1000
+)
+
+// SubmitBidFeedback leaves a review on a bid on behalf of the tender organization. The rating is optional,
+// only rated reviews count towards the reputation of the bid author.
+func (s *Service) SubmitBidFeedback(ctx context.Context, username string, bidID uuid.UUID, feedback string,
+	rating int) (model.Bid, error) {
+	if feedback == "" || utf8.RuneCountInString(feedback) > maxFeedbackLength || rating < 0 || rating > model.MaxRating {
+		return model.Bid{}, model.ErrInvalidFeedback
+	}
+
+	employee, bid, tender, err := s.bidReviewer(ctx, username, bidID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	review := model.Review{
+		BidID:       bid.ID,
+		AuthorID:    employee.ID,
+		Description: feedback,
+		Rating:      rating,
+	}
+
+	review.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Bid{}, errors.WithStack(err)
+	}
+
+	_, err = s.repository.CreateReview(ctx, review, tender.OrganizationID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	return bid, nil
+}
+
+// ConfirmDelivery records whether the winner of a tender delivered on time.
+func (s *Service) ConfirmDelivery(ctx context.Context, username string, bidID uuid.UUID, onTime bool) (model.Bid, error) {
+	employee, bid, tender, err := s.bidReviewer(ctx, username, bidID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	delivery := model.Delivery{
+		BidID:      bid.ID,
+		OnTime:     onTime,
+		EmployeeID: employee.ID,
+	}
+
+	err = s.repository.ConfirmDelivery(ctx, delivery, tender.OrganizationID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	return bid, nil
+}
+
+// BidReviews lists the reviews on past bids of an author who has bid on the tender, for the organization
+// responsible for the tender.
+func (s *Service) BidReviews(ctx context.Context, username string, tenderID uuid.UUID, author string,
+	opts model.ReviewFilter) ([]model.Review, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.policy.Can(employee, model.ActionBidReview, model.Resource{OrganizationID: tender.OrganizationID}) {
+		return nil, model.ErrNoRights
+	}
+
+	bidder, err := s.repository.Employee(ctx, author)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return nil, model.ErrTenderOrBidNotFound
+		}
+		return nil, err
+	}
+
+	bids, err := s.Bids(ctx, username, model.BidFilter{TenderID: tenderID, CreatorID: bidder.ID, Limit: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bids) == 0 {
+		return nil, model.ErrTenderOrBidNotFound
+	}
+
+	opts.CreatorID = bidder.ID
+
+	return s.repository.Reviews(ctx, opts)
+}
+
+// OrganizationReputation is public to every user, it is what bidders are judged by.
+func (s *Service) OrganizationReputation(ctx context.Context, username string,
+	organizationID uuid.UUID) (model.Reputation, error) {
+	_, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Reputation{}, err
+	}
+
+	return s.repository.OrganizationReputation(ctx, organizationID)
+}
+
+// RunReputation recomputes the reputation of every bid author periodically. The computation only depends
+// on the database, so replicas running it at the same time write the same result.
+func (s *Service) RunReputation(ctx context.Context) {
+	ticker := time.NewTicker(reputationInterval)
+	defer ticker.Stop()
+
+	for {
+		err := s.recomputeReputation(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("recompute reputation")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Service) recomputeReputation(ctx context.Context) error {
+	reputations, err := s.repository.ReputationInputs(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for i := range reputations {
+		reputations[i].Rating, reputations[i].Rated = reputations[i].ComputeRating()
+		reputations[i].Computed = now
+	}
+
+	return s.repository.SaveReputations(ctx, reputations)
+}
+
+// bidReviewer checks that the employee may review the bid for its tender organization. Sealed bids are
+// reviewed once they are opened, like they are decided.
+func (s *Service) bidReviewer(ctx context.Context, username string, bidID uuid.UUID) (model.Employee, model.Bid,
+	model.Tender, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Employee{}, model.Bid{}, model.Tender{}, err
+	}
+
+	bid, err := s.Bid(ctx, username, bidID)
+	if err != nil {
+		return model.Employee{}, model.Bid{}, model.Tender{}, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: bid.TenderID})
+	if err != nil {
+		return model.Employee{}, model.Bid{}, model.Tender{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionBidReview, model.Resource{OrganizationID: tender.OrganizationID}) {
+		return model.Employee{}, model.Bid{}, model.Tender{}, model.ErrNoRights
+	}
+
+	if tender.Sealed(time.Now()) {
+		return model.Employee{}, model.Bid{}, model.Tender{}, model.ErrBidsSealed
+	}
+
+	return employee, bid, tender, nil
+}
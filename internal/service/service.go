@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -13,20 +15,116 @@ type Repository interface {
 	Tenders(ctx context.Context, opts model.TenderFilter) ([]model.Tender, error)
 	CreateTender(ctx context.Context, tender model.Tender) (model.Tender, error)
 	UpdateTender(ctx context.Context, tender model.Tender) (model.Tender, error)
-	RollbackTender(ctx context.Context, tenderID uuid.UUID, versionID int64, creatorID uuid.UUID) (model.Tender, error)
+	ScheduleTenderPublication(ctx context.Context, tenderID uuid.UUID, publishAt time.Time, employeeID uuid.UUID) (model.Tender, error)
+	CancelTenderPublication(ctx context.Context, tenderID, employeeID uuid.UUID) (model.Tender, error)
+	ClaimScheduledPublications(ctx context.Context, limit uint64, lease time.Duration) ([]model.ScheduledPublication, error)
+	PublishScheduledTender(ctx context.Context, publication model.ScheduledPublication) (model.Tender, error)
+	CloneTender(ctx context.Context, tender model.Tender, lots []model.Lot, source model.TenderVersion) (model.Tender, error)
+	RollbackTender(ctx context.Context, tenderID uuid.UUID, versionID int64, employeeID uuid.UUID) (model.Tender, error)
+	TransferTender(ctx context.Context, tenderID, ownerID, employeeID uuid.UUID) (model.Tender, error)
+	OpenTenderBids(ctx context.Context, tenderID, employeeID uuid.UUID) (model.Tender, error)
 	Bids(ctx context.Context, opts model.BidFilter) ([]model.Bid, error)
 	CreateBid(ctx context.Context, bid model.Bid) (model.Bid, error)
 	UpdateBid(ctx context.Context, bid model.Bid) (model.Bid, error)
-	RollbackBid(ctx context.Context, bidID uuid.UUID, versionID int64, creatorID uuid.UUID) (model.Bid, error)
-	SubmitBidDecision(ctx context.Context, bidID uuid.UUID, employee model.Employee, status model.BidStatus) (model.Bid, error)
+	RollbackBid(ctx context.Context, bidID uuid.UUID, versionID int64, employeeID uuid.UUID) (model.Bid, error)
+	SubmitBidDecision(ctx context.Context, decision model.BidDecision) (model.Bid, error)
+	AuditEvents(ctx context.Context, opts model.AuditFilter) ([]model.AuditEvent, error)
+	TenderVersions(ctx context.Context, tenderID uuid.UUID) ([]model.TenderVersion, error)
+	BidVersions(ctx context.Context, bidID uuid.UUID) ([]model.BidVersion, error)
+	Webhooks(ctx context.Context, organizationID uuid.UUID) ([]model.Webhook, error)
+	Webhook(ctx context.Context, webhookID uuid.UUID) (model.Webhook, error)
+	CreateWebhook(ctx context.Context, webhook model.Webhook) (model.Webhook, error)
+	DeleteWebhook(ctx context.Context, webhookID uuid.UUID) error
+	WebhookDeliveries(ctx context.Context, opts model.WebhookDeliveryFilter) ([]model.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, webhookID uuid.UUID, deliveryID int64) (model.WebhookDelivery, error)
+	Invitations(ctx context.Context, opts model.InvitationFilter) ([]model.Invitation, error)
+	CreateInvitation(ctx context.Context, invitation model.Invitation) (model.Invitation, error)
+	DeleteInvitation(ctx context.Context, invitationID uuid.UUID) error
+	RespondInvitation(ctx context.Context, invitationID uuid.UUID, status model.InvitationStatus) (model.Invitation, error)
+	Auction(ctx context.Context, tenderID uuid.UUID) (model.Auction, error)
+	CreateAuction(ctx context.Context, auction model.Auction) (model.Auction, error)
+	PlaceOffer(ctx context.Context, offer model.AuctionOffer) (model.Auction, error)
+	Lots(ctx context.Context, tenderID uuid.UUID) ([]model.Lot, error)
+	CreateLot(ctx context.Context, lot model.Lot, employeeID uuid.UUID) (model.Lot, error)
+	UpdateLot(ctx context.Context, lot model.Lot, employeeID uuid.UUID) (model.Lot, error)
+	SetLotStatus(ctx context.Context, tenderID, lotID uuid.UUID, status model.LotStatus, employeeID uuid.UUID) (model.Lot, error)
+	Criteria(ctx context.Context, tenderID uuid.UUID) ([]model.Criterion, error)
+	ReplaceCriteria(ctx context.Context, tenderID uuid.UUID, criteria []model.Criterion, employeeID uuid.UUID) ([]model.Criterion, error)
+	Scores(ctx context.Context, bidID uuid.UUID) ([]model.Score, error)
+	SaveScores(ctx context.Context, bidID, employeeID uuid.UUID, scores []model.Score) ([]model.Score, error)
+	BidTallies(ctx context.Context, tenderID uuid.UUID) ([]model.BidTally, error)
+	Questions(ctx context.Context, opts model.QuestionFilter) ([]model.Question, error)
+	CreateQuestion(ctx context.Context, question model.Question, organizationID uuid.UUID) (model.Question, error)
+	AnswerQuestion(ctx context.Context, answer model.Question, change model.Tender) (model.Question, error)
+	Attachments(ctx context.Context, entityType model.EntityType, entityID uuid.UUID) ([]model.Attachment, error)
+	Attachment(ctx context.Context, entityType model.EntityType, entityID, attachmentID uuid.UUID) (model.Attachment, error)
+	CreateAttachment(ctx context.Context, attachment model.Attachment) (model.Attachment, error)
+	RemoveAttachment(ctx context.Context, entityType model.EntityType, entityID, attachmentID, employeeID uuid.UUID) (model.Attachment, error)
+	Comments(ctx context.Context, opts model.CommentFilter) ([]model.Comment, error)
+	CreateComment(ctx context.Context, comment model.Comment, mentions []string) (model.Comment, error)
+	MarkCommentsRead(ctx context.Context, bidID, employeeID uuid.UUID) error
+	Reviews(ctx context.Context, opts model.ReviewFilter) ([]model.Review, error)
+	CreateReview(ctx context.Context, review model.Review, organizationID uuid.UUID) (model.Review, error)
+	ConfirmDelivery(ctx context.Context, delivery model.Delivery, organizationID uuid.UUID) error
+	OrganizationReputation(ctx context.Context, organizationID uuid.UUID) (model.Reputation, error)
+	ReputationInputs(ctx context.Context) ([]model.Reputation, error)
+	SaveReputations(ctx context.Context, reputations []model.Reputation) error
+	ConflictExemptions(ctx context.Context, organizationID uuid.UUID) ([]model.ConflictExemption, error)
+	ConflictExempt(ctx context.Context, organizationID, employeeID uuid.UUID) (bool, error)
+	CreateConflictExemption(ctx context.Context, exemption model.ConflictExemption) (model.ConflictExemption, error)
+	DeleteConflictExemption(ctx context.Context, organizationID, exemptionID, employeeID uuid.UUID) error
+	RecordConflict(ctx context.Context, conflict model.Conflict) error
+	BlacklistEntries(ctx context.Context, organizationID uuid.UUID) ([]model.BlacklistEntry, error)
+	CreateBlacklistEntry(ctx context.Context, entry model.BlacklistEntry) (model.BlacklistEntry, error)
+	DeleteBlacklistEntry(ctx context.Context, organizationID, entryID, employeeID uuid.UUID) error
+	Workflows(ctx context.Context, organizationID uuid.UUID) ([]model.Workflow, error)
+	TenderWorkflow(ctx context.Context, organizationID, categoryID uuid.UUID) (model.Workflow, error)
+	SaveWorkflow(ctx context.Context, workflow model.Workflow) (model.Workflow, error)
+	DeleteWorkflow(ctx context.Context, organizationID, workflowID, employeeID uuid.UUID) error
+	StageResults(ctx context.Context, bidID uuid.UUID) ([]model.StageResult, error)
+	Delegations(ctx context.Context, organizationID uuid.UUID) ([]model.Delegation, error)
+	Delegation(ctx context.Context, organizationID, delegationID uuid.UUID) (model.Delegation, error)
+	Delegated(ctx context.Context, organizationID, delegatorID, delegateID uuid.UUID, at time.Time) (bool, error)
+	CreateDelegation(ctx context.Context, delegation model.Delegation) (model.Delegation, error)
+	DeleteDelegation(ctx context.Context, organizationID, delegationID, employeeID uuid.UUID) error
+	Categories(ctx context.Context) ([]model.Category, error)
+	Category(ctx context.Context, categoryID uuid.UUID) (model.Category, error)
+	RootCategory(ctx context.Context, name string) (model.Category, error)
+	CreateCategory(ctx context.Context, category model.Category, employeeID uuid.UUID) (model.Category, error)
+	UpdateCategory(ctx context.Context, category model.Category, employeeID uuid.UUID) (model.Category, error)
+	DeleteCategory(ctx context.Context, categoryID, employeeID uuid.UUID) error
+	TenderTemplates(ctx context.Context, organizationID uuid.UUID) ([]model.TenderTemplate, error)
+	TenderTemplate(ctx context.Context, templateID uuid.UUID) (model.TenderTemplate, error)
+	CreateTenderTemplate(ctx context.Context, template model.TenderTemplate) (model.TenderTemplate, error)
+	DeleteTenderTemplate(ctx context.Context, templateID uuid.UUID) error
+	Notifications(ctx context.Context, opts model.NotificationFilter) ([]model.Notification, error)
+	MarkNotificationRead(ctx context.Context, employeeID uuid.UUID, notificationID int64) (model.Notification, error)
+	MarkNotificationsRead(ctx context.Context, employeeID uuid.UUID) error
+	NotificationPreferences(ctx context.Context, employeeID uuid.UUID) ([]model.NotificationPreference, error)
+	SaveNotificationPreferences(ctx context.Context, employeeID uuid.UUID, preferences []model.NotificationPreference) error
+	ListenChanges(ctx context.Context, handle func(model.Change)) error
+	ChangeScope(ctx context.Context, change model.Change) (model.ChangeScope, error)
+}
+
+// Storage keeps the content of attachments, the repository only knows their metadata.
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
 }
 
 type Service struct {
 	repository Repository
+	storage    Storage
+	policy     *Policy
+	changes    *changeBroker
 }
 
-func NewService(repository Repository) *Service {
+func NewService(repository Repository, storage Storage, policy *Policy) *Service {
 	return &Service{
 		repository: repository,
+		storage:    storage,
+		policy:     policy,
+		changes:    newChangeBroker(),
 	}
 }
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+// TenderTemplates lists the templates of an organization. Templates serve the people who create tenders,
+// so every template operation needs tender.create.
+func (s *Service) TenderTemplates(ctx context.Context, username string, organizationID uuid.UUID) ([]model.TenderTemplate, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderCreate, model.Resource{OrganizationID: organizationID}) {
+		return nil, model.ErrNoRights
+	}
+
+	return s.repository.TenderTemplates(ctx, organizationID)
+}
+
+func (s *Service) CreateTenderTemplate(ctx context.Context, username string,
+	template model.TenderTemplate) (model.TenderTemplate, error) {
+	if template.Name == "" || template.TenderName == "" {
+		return model.TenderTemplate{}, model.ErrInvalidTemplate
+	}
+
+	if template.Visibility == "" {
+		template.Visibility = model.TenderVisibilityPublic
+	}
+
+	if !template.Visibility.Valid() {
+		return model.TenderTemplate{}, model.ErrInvalidVisibility
+	}
+
+	if template.BidMode == "" {
+		template.BidMode = model.TenderBidModeOpen
+	}
+
+	if !template.BidMode.Valid() {
+		return model.TenderTemplate{}, model.ErrInvalidBidMode
+	}
+
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.TenderTemplate{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderCreate, model.Resource{OrganizationID: template.OrganizationID}) {
+		return model.TenderTemplate{}, model.ErrNoRights
+	}
+
+	category, err := s.category(ctx, template.CategoryID, template.Category)
+	if err != nil {
+		return model.TenderTemplate{}, err
+	}
+
+	template.CategoryID = category.ID
+
+	template.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.TenderTemplate{}, errors.WithStack(err)
+	}
+
+	template.CreatorID = employee.ID
+
+	return s.repository.CreateTenderTemplate(ctx, template)
+}
+
+func (s *Service) DeleteTenderTemplate(ctx context.Context, username string, templateID uuid.UUID) error {
+	_, err := s.ownTemplate(ctx, username, templateID)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteTenderTemplate(ctx, templateID)
+}
+
+// CreateTenderFromTemplate fills the placeholders of the template and creates the tender like POST /tenders/new.
+// The date, month and year placeholders default to the current date.
+func (s *Service) CreateTenderFromTemplate(ctx context.Context, username string, templateID uuid.UUID,
+	values map[string]string) (model.Tender, error) {
+	template, err := s.ownTemplate(ctx, username, templateID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	now := time.Now()
+
+	filled := map[string]string{
+		"date":  now.Format(time.DateOnly),
+		"month": now.Month().String(),
+		"year":  strconv.Itoa(now.Year()),
+	}
+	maps.Copy(filled, values)
+
+	tender, err := template.Fill(filled)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	return s.CreateTender(ctx, username, tender)
+}
+
+func (s *Service) ownTemplate(ctx context.Context, username string, templateID uuid.UUID) (model.TenderTemplate, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.TenderTemplate{}, err
+	}
+
+	template, err := s.repository.TenderTemplate(ctx, templateID)
+	if err != nil {
+		return model.TenderTemplate{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderCreate, model.Resource{OrganizationID: template.OrganizationID}) {
+		return model.TenderTemplate{}, model.ErrTemplateNotFound
+	}
+
+	return template, nil
+}
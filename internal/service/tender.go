@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"slices"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/uuid"
@@ -20,7 +21,17 @@ func (s *Service) Tenders(ctx context.Context, username string, opts model.Tende
 		opts.CreatorID = employee.ID
 	}
 
-	opts.OrganizationIDs = employee.OrganizationIDs
+	opts.EmployeeID = employee.ID
+	opts.OrganizationIDs = s.policy.Organizations(employee, model.ActionTenderView)
+
+	if opts.CategoryID != uuid.Nil || opts.Category != "" {
+		category, err := s.category(ctx, opts.CategoryID, opts.Category)
+		if err != nil {
+			return nil, err
+		}
+
+		opts.CategoryID = category.ID
+	}
 
 	tenders, err := s.repository.Tenders(ctx, opts)
 	if err != nil {
@@ -49,10 +60,37 @@ func (s *Service) CreateTender(ctx context.Context, username string, tender mode
 		return model.Tender{}, err
 	}
 
-	if !slices.Contains(employee.OrganizationIDs, tender.OrganizationID) {
-		return model.Tender{}, errors.New("insufficient rights to perform the action")
+	if !s.policy.Can(employee, model.ActionTenderCreate, model.Resource{OrganizationID: tender.OrganizationID}) {
+		return model.Tender{}, model.ErrNoRights
+	}
+
+	if tender.Visibility == "" {
+		tender.Visibility = model.TenderVisibilityPublic
+	}
+
+	if !tender.Visibility.Valid() {
+		return model.Tender{}, model.ErrInvalidVisibility
+	}
+
+	if tender.BidMode == "" {
+		tender.BidMode = model.TenderBidModeOpen
+	}
+
+	if !tender.BidMode.Valid() {
+		return model.Tender{}, model.ErrInvalidBidMode
+	}
+
+	if !tender.PublishAt.IsZero() && !tender.PublishAt.After(time.Now()) {
+		return model.Tender{}, model.ErrInvalidPublishAt
+	}
+
+	category, err := s.category(ctx, tender.CategoryID, tender.Category)
+	if err != nil {
+		return model.Tender{}, err
 	}
 
+	tender.CategoryID = category.ID
+
 	tender.ID, err = uuid.NewV7()
 	if err != nil {
 		return model.Tender{}, errors.WithStack(err)
@@ -70,6 +108,10 @@ func (s *Service) CreateTender(ctx context.Context, username string, tender mode
 }
 
 func (s *Service) UpdateTender(ctx context.Context, username string, tender model.Tender) (model.Tender, error) {
+	if tender.Visibility != "" && !tender.Visibility.Valid() {
+		return model.Tender{}, model.ErrInvalidVisibility
+	}
+
 	employee, err := s.repository.Employee(ctx, username)
 	if err != nil {
 		return model.Tender{}, err
@@ -79,11 +121,29 @@ func (s *Service) UpdateTender(ctx context.Context, username string, tender mode
 		TenderID: tender.ID,
 	}
 
-	_, err = s.Tender(ctx, username, opts)
+	current, err := s.Tender(ctx, username, opts)
 	if err != nil {
 		return model.Tender{}, err
 	}
 
+	action := model.ActionTenderEdit
+	if tender.Status != "" {
+		action = model.ActionTenderStatus
+	}
+
+	if !s.policy.Can(employee, action, s.tenderResource(current)) {
+		return model.Tender{}, model.ErrNoRights
+	}
+
+	if tender.CategoryID != uuid.Nil || tender.Category != "" {
+		category, err := s.category(ctx, tender.CategoryID, tender.Category)
+		if err != nil {
+			return model.Tender{}, err
+		}
+
+		tender.CategoryID = category.ID
+	}
+
 	tender.CreatorID = employee.ID
 
 	t, err := s.repository.UpdateTender(ctx, tender)
@@ -101,15 +161,18 @@ func (s *Service) RollbackTender(ctx context.Context, username string, tenderID
 	}
 
 	opts := model.TenderFilter{
-		TenderID:  tenderID,
-		VersionID: versionID,
+		TenderID: tenderID,
 	}
 
-	_, err = s.Tender(ctx, username, opts)
+	current, err := s.Tender(ctx, username, opts)
 	if err != nil {
 		return model.Tender{}, err
 	}
 
+	if !s.policy.Can(employee, model.ActionTenderRollback, s.tenderResource(current)) {
+		return model.Tender{}, model.ErrNoRights
+	}
+
 	t, err := s.repository.RollbackTender(ctx, tenderID, versionID, employee.ID)
 	if err != nil {
 		return model.Tender{}, err
@@ -117,3 +180,145 @@ func (s *Service) RollbackTender(ctx context.Context, username string, tenderID
 
 	return t, nil
 }
+
+func (s *Service) TransferTender(ctx context.Context, username string, tenderID uuid.UUID, owner string) (model.Tender, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	current, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderTransfer, s.tenderResource(current)) {
+		return model.Tender{}, model.ErrNoRights
+	}
+
+	newOwner, err := s.repository.Employee(ctx, owner)
+	if err != nil {
+		if errors.Is(err, model.ErrUserNotFound) {
+			return model.Tender{}, model.ErrInvalidOwner
+		}
+		return model.Tender{}, err
+	}
+
+	if !s.policy.Can(newOwner, model.ActionTenderEdit, s.tenderResource(current)) {
+		return model.Tender{}, model.ErrInvalidOwner
+	}
+
+	t, err := s.repository.TransferTender(ctx, tenderID, newOwner.ID, employee.ID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	return t, nil
+}
+
+func (s *Service) OpenTenderBids(ctx context.Context, username string, tenderID uuid.UUID) (model.Tender, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	current, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderOpenBids, s.tenderResource(current)) {
+		return model.Tender{}, model.ErrNoRights
+	}
+
+	t, err := s.repository.OpenTenderBids(ctx, tenderID, employee.ID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	return t, nil
+}
+
+// CloneTender starts a new tender of the same organization from a version of an existing one, the current
+// version without versionID. Lots that are still open or were awarded are copied as open lots; the deadline,
+// bids and attachments belong to the original tender and are not copied.
+func (s *Service) CloneTender(ctx context.Context, username string, tenderID uuid.UUID, versionID int64) (model.Tender, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	current, err := s.Tender(ctx, username, model.TenderFilter{TenderID: tenderID})
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderCreate, model.Resource{OrganizationID: current.OrganizationID}) {
+		return model.Tender{}, model.ErrNoRights
+	}
+
+	if versionID == 0 {
+		versionID = current.VersionID
+	}
+
+	versions, err := s.repository.TenderVersions(ctx, tenderID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	i := slices.IndexFunc(versions, func(v model.TenderVersion) bool { return v.ID == versionID })
+	if i < 0 {
+		return model.Tender{}, model.ErrTenderOrVersionNotFound
+	}
+
+	source := versions[i]
+
+	tender := model.Tender{
+		Name:           source.Name,
+		Description:    source.Description,
+		CategoryID:     source.CategoryID,
+		Status:         model.TenderStatusCreated,
+		Visibility:     current.Visibility,
+		BidMode:        current.BidMode,
+		OrganizationID: current.OrganizationID,
+		CreatorID:      employee.ID,
+	}
+
+	// Versions from before the taxonomy only kept the service type name.
+	if tender.CategoryID == uuid.Nil {
+		tender.CategoryID = current.CategoryID
+	}
+
+	// Auctions are set up on an open tender, the clone starts as one.
+	if !tender.BidMode.Valid() {
+		tender.BidMode = model.TenderBidModeOpen
+	}
+
+	tender.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Tender{}, errors.WithStack(err)
+	}
+
+	var lots []model.Lot
+	for _, l := range source.Lots {
+		if l.Status != model.LotStatusOpen && l.Status != model.LotStatusAwarded {
+			continue
+		}
+
+		id, err := uuid.NewV7()
+		if err != nil {
+			return model.Tender{}, errors.WithStack(err)
+		}
+
+		lots = append(lots, model.Lot{ID: id, Name: l.Name, Description: l.Description})
+	}
+
+	return s.repository.CloneTender(ctx, tender, lots, source)
+}
+
+func (s *Service) tenderResource(tender model.Tender) model.Resource {
+	return model.Resource{
+		OrganizationID: tender.OrganizationID,
+		CreatorID:      tender.CreatorID,
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/url"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+func (s *Service) Webhooks(ctx context.Context, username string, organizationID uuid.UUID) ([]model.Webhook, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.policy.Can(employee, model.ActionWebhookManage, model.Resource{OrganizationID: organizationID}) {
+		return nil, model.ErrNoRights
+	}
+
+	return s.repository.Webhooks(ctx, organizationID)
+}
+
+func (s *Service) CreateWebhook(ctx context.Context, username string, webhook model.Webhook) (model.Webhook, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionWebhookManage, model.Resource{OrganizationID: webhook.OrganizationID}) {
+		return model.Webhook{}, model.ErrNoRights
+	}
+
+	err = checkWebhookURL(ctx, webhook.URL)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+
+	for _, t := range webhook.EventTypes {
+		if !t.Valid() {
+			return model.Webhook{}, model.ErrInvalidWebhookEventType
+		}
+	}
+
+	webhook.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Webhook{}, errors.WithStack(err)
+	}
+
+	if webhook.Secret == "" {
+		secret := make([]byte, 32)
+
+		_, err = rand.Read(secret)
+		if err != nil {
+			return model.Webhook{}, errors.WithStack(err)
+		}
+
+		webhook.Secret = hex.EncodeToString(secret)
+	}
+
+	webhook.CreatorID = employee.ID
+
+	return s.repository.CreateWebhook(ctx, webhook)
+}
+
+func (s *Service) DeleteWebhook(ctx context.Context, username string, webhookID uuid.UUID) error {
+	_, err := s.ownWebhook(ctx, username, webhookID)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteWebhook(ctx, webhookID)
+}
+
+func (s *Service) WebhookDeliveries(ctx context.Context, username string, opts model.WebhookDeliveryFilter) ([]model.WebhookDelivery, error) {
+	_, err := s.ownWebhook(ctx, username, opts.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repository.WebhookDeliveries(ctx, opts)
+}
+
+func (s *Service) RedeliverWebhook(ctx context.Context, username string, webhookID uuid.UUID, deliveryID int64) (model.WebhookDelivery, error) {
+	_, err := s.ownWebhook(ctx, username, webhookID)
+	if err != nil {
+		return model.WebhookDelivery{}, err
+	}
+
+	return s.repository.RedeliverWebhook(ctx, webhookID, deliveryID)
+}
+
+func (s *Service) ownWebhook(ctx context.Context, username string, webhookID uuid.UUID) (model.Webhook, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+
+	webhook, err := s.repository.Webhook(ctx, webhookID)
+	if err != nil {
+		return model.Webhook{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionWebhookManage, model.Resource{OrganizationID: webhook.OrganizationID}) {
+		return model.Webhook{}, model.ErrWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+// checkWebhookURL refuses urls whose host resolves to an internal address. The sender checks the address again
+// on every connection, since the name may resolve differently by the time deliveries are made.
+func checkWebhookURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return model.ErrInvalidWebhookURL
+	}
+
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", u.Hostname())
+	if err != nil || len(addrs) == 0 {
+		return model.ErrInvalidWebhookURL
+	}
+
+	for _, addr := range addrs {
+		if !model.PublicAddress(addr) {
+			return model.ErrInvalidWebhookURL
+		}
+	}
+
+	return nil
+}
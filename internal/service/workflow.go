@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/uuid"
+
+	"zadanie-6105/internal/model"
+)
+
+// Workflows lists the approval workflows of the organization to its members.
+func (s *Service) Workflows(ctx context.Context, username string, organizationID uuid.UUID) ([]model.Workflow, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderView, model.Resource{OrganizationID: organizationID}) {
+		return nil, model.ErrNoRights
+	}
+
+	return s.repository.Workflows(ctx, organizationID)
+}
+
+// SaveWorkflow sets the approval workflow of the organization for a category, or for all its other tenders
+// when no category is given. Stage approvers are given by username and must be allowed to decide on bids
+// of the organization.
+func (s *Service) SaveWorkflow(ctx context.Context, username string, workflow model.Workflow,
+	approvers [][]string) (model.Workflow, error) {
+	if workflow.Name == "" || len(workflow.Stages) == 0 || len(workflow.Stages) != len(approvers) {
+		return model.Workflow{}, model.ErrInvalidWorkflow
+	}
+
+	employee, err := s.workflowManager(ctx, username, workflow.OrganizationID)
+	if err != nil {
+		return model.Workflow{}, err
+	}
+
+	if workflow.CategoryID != uuid.Nil {
+		_, err = s.repository.Category(ctx, workflow.CategoryID)
+		if err != nil {
+			return model.Workflow{}, err
+		}
+	}
+
+	for i := range workflow.Stages {
+		stage := &workflow.Stages[i]
+
+		stage.ApproverIDs, err = s.stageApprovers(ctx, workflow.OrganizationID, approvers[i])
+		if err != nil {
+			return model.Workflow{}, err
+		}
+
+		if stage.Name == "" || stage.Quorum < 1 || stage.Quorum > len(stage.ApproverIDs) {
+			return model.Workflow{}, model.ErrInvalidWorkflow
+		}
+	}
+
+	workflow.ID, err = uuid.NewV7()
+	if err != nil {
+		return model.Workflow{}, errors.WithStack(err)
+	}
+
+	workflow.CreatorID = employee.ID
+
+	return s.repository.SaveWorkflow(ctx, workflow)
+}
+
+func (s *Service) DeleteWorkflow(ctx context.Context, username string, organizationID, workflowID uuid.UUID) error {
+	employee, err := s.workflowManager(ctx, username, organizationID)
+	if err != nil {
+		return err
+	}
+
+	return s.repository.DeleteWorkflow(ctx, organizationID, workflowID, employee.ID)
+}
+
+// BidStages is the approval history of a bid for the members of the tender organization.
+func (s *Service) BidStages(ctx context.Context, username string, bidID uuid.UUID) ([]model.StageResult, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	bid, err := s.Bid(ctx, username, bidID)
+	if err != nil {
+		return nil, err
+	}
+
+	tender, err := s.Tender(ctx, username, model.TenderFilter{TenderID: bid.TenderID})
+	if err != nil {
+		return nil, err
+	}
+
+	if !s.policy.Can(employee, model.ActionTenderView, model.Resource{OrganizationID: tender.OrganizationID}) {
+		return nil, model.ErrNoRights
+	}
+
+	return s.repository.StageResults(ctx, bid.ID)
+}
+
+// tenderWorkflow is the workflow decisions on the bids of the tender go through, empty when there is none.
+func (s *Service) tenderWorkflow(ctx context.Context, tender model.Tender) (model.Workflow, error) {
+	workflow, err := s.repository.TenderWorkflow(ctx, tender.OrganizationID, tender.CategoryID)
+	if err != nil {
+		if errors.Is(err, model.ErrWorkflowNotFound) {
+			return model.Workflow{}, nil
+		}
+		return model.Workflow{}, err
+	}
+
+	return workflow, nil
+}
+
+func (s *Service) stageApprovers(ctx context.Context, organizationID uuid.UUID, usernames []string) ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(usernames))
+
+	for _, username := range usernames {
+		approver, err := s.repository.Employee(ctx, username)
+		if err != nil {
+			if errors.Is(err, model.ErrUserNotFound) {
+				return nil, model.ErrInvalidWorkflow
+			}
+			return nil, err
+		}
+
+		if !s.policy.Can(approver, model.ActionBidDecide, model.Resource{OrganizationID: organizationID}) {
+			return nil, model.ErrInvalidWorkflow
+		}
+
+		ids = append(ids, approver.ID)
+	}
+
+	return uniqueIDs(ids), nil
+}
+
+func (s *Service) workflowManager(ctx context.Context, username string, organizationID uuid.UUID) (model.Employee, error) {
+	employee, err := s.repository.Employee(ctx, username)
+	if err != nil {
+		return model.Employee{}, err
+	}
+
+	if !s.policy.Can(employee, model.ActionWorkflowManage, model.Resource{OrganizationID: organizationID}) {
+		return model.Employee{}, model.ErrNoRights
+	}
+
+	return employee, nil
+}
@@ -0,0 +1,100 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/cockroachdb/errors"
+)
+
+// ErrNotFound is returned for keys that hold no object.
+var ErrNotFound = errors.New("object not found")
+
+// Local keeps objects as files below a root directory, keys being slash separated relative paths.
+type Local struct {
+	root string
+}
+
+func NewLocal(root string) (*Local, error) {
+	err := os.MkdirAll(root, 0o750)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &Local{root: root}, nil
+}
+
+// Put writes to a temporary file first so that a failed upload never leaves a partial object behind.
+func (l *Local) Put(_ context.Context, key string, content io.Reader, _ int64, _ string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o750)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+
+	_, err = io.Copy(f, content)
+	if err != nil {
+		_ = f.Close()
+		return errors.WithStack(err)
+	}
+
+	err = f.Close()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return errors.WithStack(os.Rename(f.Name(), path))
+}
+
+func (l *Local) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, errors.WithStack(ErrNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return f, nil
+}
+
+func (l *Local) Delete(_ context.Context, key string) error {
+	path, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// path keeps keys below the root; a key naming the root itself is no object either.
+func (l *Local) path(key string) (string, error) {
+	name := filepath.FromSlash(key)
+	if !filepath.IsLocal(name) || filepath.Clean(name) == "." {
+		return "", errors.Newf("invalid object key %q", key)
+	}
+
+	return filepath.Join(l.root, name), nil
+}
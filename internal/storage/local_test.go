@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+)
+
+func TestLocalRoundTrip(t *testing.T) {
+	ctx := context.Background()
+
+	store, err := NewLocal(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testRoundTrip(t, ctx, store)
+}
+
+func TestLocalRejectsKeysOutsideRoot(t *testing.T) {
+	ctx := context.Background()
+	parent := t.TempDir()
+	root := filepath.Join(parent, "root")
+
+	store, err := NewLocal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"../escape", "a/../../escape", "/etc/passwd", "", ".", "a/.."} {
+		err := store.Put(ctx, key, strings.NewReader("x"), 1, "text/plain")
+		if err == nil {
+			t.Errorf("Put(%q) succeeded, want an invalid key error", key)
+		}
+
+		_, err = store.Get(ctx, key)
+		if err == nil || errors.Is(err, ErrNotFound) {
+			t.Errorf("Get(%q) error = %v, want an invalid key error", key, err)
+		}
+
+		err = store.Delete(ctx, key)
+		if err == nil {
+			t.Errorf("Delete(%q) succeeded, want an invalid key error", key)
+		}
+	}
+
+	_, err = os.Stat(filepath.Join(parent, "escape"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("an object was written outside the root: %v", err)
+	}
+}
+
+// testRoundTrip runs the checks every Storage implementation has to pass.
+func testRoundTrip(t *testing.T, ctx context.Context, store interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}) {
+	t.Helper()
+
+	const key, content = "tender/0191f5c0/attachment.txt", "attachment content"
+
+	err := store.Put(ctx, key, strings.NewReader(content), int64(len(content)), "text/plain")
+	if err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	r, err := store.Get(ctx, key)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	got, err := io.ReadAll(r)
+	_ = r.Close()
+	if err != nil {
+		t.Fatalf("reading the object: %v", err)
+	}
+
+	if string(got) != content {
+		t.Errorf("Get() = %q, want %q", got, content)
+	}
+
+	err = store.Delete(ctx, key)
+	if err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	_, err = store.Get(ctx, key)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Get() after Delete() error = %v, want ErrNotFound", err)
+	}
+
+	err = store.Delete(ctx, key)
+	if err != nil {
+		t.Errorf("Delete() of a missing object error = %v", err)
+	}
+}
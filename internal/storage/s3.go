@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"context"
+	"io"
+
+	"github.com/cockroachdb/errors"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3 keeps objects in a bucket of any S3 compatible service, MinIO included.
+type S3 struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3 connects to the endpoint and creates the bucket when it does not exist yet.
+func NewS3(ctx context.Context, endpoint, accessKey, secretKey, bucket string, secure bool) (*S3, error) {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: secure,
+	})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if !exists {
+		err = client.MakeBucket(ctx, bucket, minio.MakeBucketOptions{})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	return &S3{client: client, bucket: bucket}, nil
+}
+
+func (s *S3) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, content, size, minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// Get checks the object exists before handing out the reader, minio only fails on the first read otherwise.
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	_, err = object.Stat()
+	if err != nil {
+		_ = object.Close()
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return nil, errors.WithStack(ErrNotFound)
+		}
+		return nil, errors.WithStack(err)
+	}
+
+	return object, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
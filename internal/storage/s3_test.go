@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+// TestS3RoundTrip runs against the S3 compatible service given by the same variables as the server, for example
+// a local MinIO: S3_ENDPOINT=localhost:9000 S3_ACCESS_KEY=minioadmin S3_SECRET_KEY=minioadmin.
+func TestS3RoundTrip(t *testing.T) {
+	endpoint := os.Getenv("S3_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_ENDPOINT is not set")
+	}
+
+	bucket := os.Getenv("S3_BUCKET")
+	if bucket == "" {
+		bucket = "attachments-test"
+	}
+
+	ctx := context.Background()
+
+	store, err := NewS3(ctx, endpoint, os.Getenv("S3_ACCESS_KEY"), os.Getenv("S3_SECRET_KEY"), bucket,
+		os.Getenv("S3_SECURE") == "true")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testRoundTrip(t, ctx, store)
+}
@@ -0,0 +1,232 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/rs/zerolog/log"
+
+	"zadanie-6105/internal/model"
+	"zadanie-6105/internal/outbox"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	defaultInterval    = time.Second
+	defaultBatch       = 50
+	defaultLease       = time.Minute
+	defaultTimeout     = 10 * time.Second
+	defaultMaxAttempts = 10
+	defaultMinBackoff  = 5 * time.Second
+	defaultMaxBackoff  = 6 * time.Hour
+	maxRedirects       = 10
+)
+
+type Repository interface {
+	CreateWebhookDeliveries(ctx context.Context, event model.Event, payload json.RawMessage) error
+	ClaimWebhookDeliveries(ctx context.Context, limit uint64, lease time.Duration) ([]model.WebhookDelivery, error)
+	MarkWebhookDelivered(ctx context.Context, deliveryID int64, responseCode int) error
+	MarkWebhookFailed(ctx context.Context, delivery model.WebhookDelivery) error
+}
+
+// Dispatcher is an outbox publisher that turns every event into pending deliveries for matching subscriptions.
+type Dispatcher struct {
+	repository Repository
+}
+
+func NewDispatcher(repository Repository) *Dispatcher {
+	return &Dispatcher{repository: repository}
+}
+
+func (d *Dispatcher) Publish(ctx context.Context, event model.Event) error {
+	payload, err := outbox.EncodeEvent(event)
+	if err != nil {
+		return err
+	}
+
+	return d.repository.CreateWebhookDeliveries(ctx, event, payload)
+}
+
+type Sender struct {
+	repository  Repository
+	client      *http.Client
+	interval    time.Duration
+	batch       uint64
+	lease       time.Duration
+	maxAttempts int
+}
+
+// NewSender delivers with the client given, or without one with a client that only connects to public addresses.
+func NewSender(repository Repository, client *http.Client) *Sender {
+	if client == nil {
+		client = newClient()
+	}
+
+	return &Sender{
+		repository:  repository,
+		client:      client,
+		interval:    defaultInterval,
+		batch:       defaultBatch,
+		lease:       defaultLease,
+		maxAttempts: defaultMaxAttempts,
+	}
+}
+
+func (s *Sender) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		err := s.send(ctx)
+		if err != nil {
+			log.Error().Stack().Err(err).Msg("webhook sender")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Sender) send(ctx context.Context) error {
+	deliveries, err := s.repository.ClaimWebhookDeliveries(ctx, s.batch, s.lease)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range deliveries {
+		code, err := s.deliver(ctx, d)
+		if err == nil {
+			err = s.repository.MarkWebhookDelivered(ctx, d.ID, code)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		d.ResponseCode = code
+		d.LastError = err.Error()
+		d.NextAttempt = time.Now().Add(backoff(d.Attempts))
+		d.Status = model.WebhookDeliveryPending
+		if d.Attempts+1 >= s.maxAttempts {
+			d.Status = model.WebhookDeliveryFailed
+		}
+
+		err = s.repository.MarkWebhookFailed(ctx, d)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Sender) deliver(ctx context.Context, d model.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(d.EventType))
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(d.ID, 10))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, "sha256="+Sign(d.Secret, timestamp, d.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, errors.WithStack(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, errors.Newf("unexpected response status %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+// newClient checks the address of every connection, so neither a name resolving to an internal address nor a
+// redirect to one gets a delivery through.
+func newClient() *http.Client {
+	dialer := &net.Dialer{Timeout: defaultTimeout, Control: publicOnly}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:       defaultTimeout,
+		Transport:     transport,
+		CheckRedirect: checkRedirect,
+	}
+}
+
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if !model.PublicAddress(addrPort.Addr()) {
+		return errors.WithStack(model.ErrWebhookAddress)
+	}
+
+	return nil
+}
+
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return errors.Newf("stopped after %d redirects", maxRedirects)
+	}
+
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return errors.Newf("redirect to unsupported scheme %q", req.URL.Scheme)
+	}
+
+	return nil
+}
+
+// Sign covers the timestamp as well as the body, so receivers can reject replayed deliveries.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = fmt.Fprintf(mac, "%s.", timestamp)
+	_, _ = mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func backoff(attempts int) time.Duration {
+	d := defaultMinBackoff
+	for i := 0; i < attempts && d < defaultMaxBackoff; i++ {
+		d *= 2
+	}
+
+	return min(d, defaultMaxBackoff)
+}
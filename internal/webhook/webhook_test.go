@@ -0,0 +1,192 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"zadanie-6105/internal/model"
+)
+
+func TestSign(t *testing.T) {
+	payload := []byte(`{"id":1}`)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("1700000000." + string(payload)))
+	want := hex.EncodeToString(mac.Sum(nil))
+
+	if got := Sign("secret", "1700000000", payload); got != want {
+		t.Errorf("Sign() = %s, want %s", got, want)
+	}
+
+	if Sign("secret", "1700000001", payload) == want {
+		t.Error("Sign() does not cover the timestamp")
+	}
+
+	if Sign("other", "1700000000", payload) == want {
+		t.Error("Sign() does not depend on the secret")
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 5 * time.Second},
+		{1, 10 * time.Second},
+		{2, 20 * time.Second},
+		{10, 5 * time.Second * 1024},
+		{13, defaultMaxBackoff},
+		{1000, defaultMaxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestSenderSend(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []*http.Request
+		bodies   [][]byte
+	)
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		mu.Unlock()
+
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	payload := json.RawMessage(`{"id":7}`)
+
+	repository := &fakeRepository{
+		deliveries: []model.WebhookDelivery{
+			{ID: 1, EventType: model.EventBidSubmitted, Payload: payload, URL: receiver.URL + "/ok", Secret: "s1"},
+			{ID: 2, EventType: model.EventBidSubmitted, Payload: payload, URL: receiver.URL + "/fail", Secret: "s2",
+				Attempts: 2},
+			{ID: 3, EventType: model.EventBidSubmitted, Payload: payload, URL: receiver.URL + "/fail", Secret: "s3",
+				Attempts: defaultMaxAttempts - 1},
+		},
+	}
+
+	sender := NewSender(repository, receiver.Client())
+
+	start := time.Now()
+
+	err := sender.send(context.Background())
+	if err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+
+	if len(requests) != 3 {
+		t.Fatalf("receiver got %d requests, want 3", len(requests))
+	}
+
+	for i, r := range requests {
+		d := repository.deliveries[i]
+		timestamp := r.Header.Get(HeaderTimestamp)
+
+		if got, want := r.Header.Get(HeaderSignature), "sha256="+Sign(d.Secret, timestamp, bodies[i]); got != want {
+			t.Errorf("delivery %d signature = %s, want %s", d.ID, got, want)
+		}
+
+		if got := r.Header.Get(HeaderDelivery); got != strconv.FormatInt(d.ID, 10) {
+			t.Errorf("delivery %d delivery header = %s", d.ID, got)
+		}
+
+		if got := r.Header.Get(HeaderEvent); got != string(model.EventBidSubmitted) {
+			t.Errorf("delivery %d event header = %s", d.ID, got)
+		}
+	}
+
+	if len(repository.delivered) != 1 || repository.delivered[0] != 1 {
+		t.Errorf("delivered = %v, want [1]", repository.delivered)
+	}
+
+	if len(repository.failed) != 2 {
+		t.Fatalf("failed = %d deliveries, want 2", len(repository.failed))
+	}
+
+	retried := repository.failed[0]
+	if retried.Status != model.WebhookDeliveryPending {
+		t.Errorf("delivery 2 status = %s, want %s", retried.Status, model.WebhookDeliveryPending)
+	}
+
+	if retried.ResponseCode != http.StatusInternalServerError || retried.LastError == "" {
+		t.Errorf("delivery 2 response = %d %q", retried.ResponseCode, retried.LastError)
+	}
+
+	if wait := retried.NextAttempt.Sub(start); wait < backoff(2) || wait > backoff(2)+time.Minute {
+		t.Errorf("delivery 2 next attempt in %s, want %s", wait, backoff(2))
+	}
+
+	if given := repository.failed[1]; given.Status != model.WebhookDeliveryFailed {
+		t.Errorf("delivery 3 status = %s, want %s", given.Status, model.WebhookDeliveryFailed)
+	}
+}
+
+func TestSenderRefusesInternalAddress(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		t.Error("delivery reached a loopback receiver")
+	}))
+	defer receiver.Close()
+
+	repository := &fakeRepository{
+		deliveries: []model.WebhookDelivery{{ID: 1, Payload: json.RawMessage(`{}`), URL: receiver.URL}},
+	}
+
+	err := NewSender(repository, nil).send(context.Background())
+	if err != nil {
+		t.Fatalf("send() error = %v", err)
+	}
+
+	if len(repository.failed) != 1 || repository.failed[0].ResponseCode != 0 {
+		t.Errorf("failed = %+v, want the delivery failed without a response", repository.failed)
+	}
+}
+
+type fakeRepository struct {
+	Repository
+
+	deliveries []model.WebhookDelivery
+	delivered  []int64
+	failed     []model.WebhookDelivery
+}
+
+func (r *fakeRepository) ClaimWebhookDeliveries(context.Context, uint64, time.Duration) ([]model.WebhookDelivery, error) {
+	return r.deliveries, nil
+}
+
+func (r *fakeRepository) MarkWebhookDelivered(_ context.Context, deliveryID int64, _ int) error {
+	r.delivered = append(r.delivered, deliveryID)
+	return nil
+}
+
+func (r *fakeRepository) MarkWebhookFailed(_ context.Context, delivery model.WebhookDelivery) error {
+	r.failed = append(r.failed, delivery)
+	return nil
+}